@@ -0,0 +1,90 @@
+// Package main demonstrates the usage of the xstr BER-TLV parsing and encoding functionality.
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	xstr "github.com/hotfixfirst/go-xstr"
+)
+
+func main() {
+	fmt.Println("=== BER-TLV Examples ===")
+	fmt.Println()
+
+	// Example 1: Parse a constructed FCI template
+	fmt.Println("1. ParseBERTLVHex - Parse a tag tree")
+	fmt.Println("------------------------------------")
+
+	data := "6F1B8407A0000000031010A510500B56495341204352454449549F3800"
+	fmt.Printf("Data: %s\n\n", data)
+
+	nodes, err := xstr.ParseBERTLVHex(data)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	printTree(nodes, 1)
+
+	fmt.Println()
+
+	// Example 2: Find a tag anywhere in the tree
+	fmt.Println("2. FindBERTLV - Depth-first search")
+	fmt.Println("----------------------------------")
+	for _, tag := range []string{"84", "50", "9F26"} {
+		node, found := xstr.FindBERTLV(nodes, tag)
+		if !found {
+			fmt.Printf("  %-4s -> not found\n", tag)
+			continue
+		}
+		fmt.Printf("  %-4s -> %X (%s)\n", tag, node.Value, node.Name())
+	}
+
+	fmt.Println()
+
+	// Example 3: Encode a tree with long-form lengths
+	fmt.Println("3. EncodeBERTLVHex - Encode a tag tree")
+	fmt.Println("--------------------------------------")
+
+	encoded, err := xstr.EncodeBERTLVHex([]xstr.BERTLV{
+		{Tag: "70", Children: []xstr.BERTLV{
+			{Tag: "5A", Value: []byte{0x41, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11}},
+			{Tag: "9F10", Value: make([]byte, 130)},
+		}},
+	})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	fmt.Printf("  Length:  %d hex characters\n", len(encoded))
+	fmt.Printf("  Prefix:  %s...\n", encoded[:40])
+
+	fmt.Println()
+
+	// Example 4: Error handling
+	fmt.Println("4. Error handling")
+	fmt.Println("-----------------")
+	for _, invalid := range []string{"5A08411111", "ZZ", "9F"} {
+		_, err := xstr.ParseBERTLVHex(invalid)
+		fmt.Printf("  %-12s -> %v\n", invalid, err)
+	}
+
+	fmt.Println()
+	fmt.Println("=== End of Examples ===")
+}
+
+func printTree(nodes []xstr.BERTLV, depth int) {
+	for _, node := range nodes {
+		indent := strings.Repeat("  ", depth)
+		if node.Constructed() {
+			fmt.Printf("%s%s (%s)\n", indent, node.Tag, node.Name())
+			printTree(node.Children, depth+1)
+			continue
+		}
+		value := fmt.Sprintf("%X", node.Value)
+		if value == "" {
+			value = "(empty)"
+		}
+		fmt.Printf("%s%s (%s): %s\n", indent, node.Tag, node.Name(), value)
+	}
+}
@@ -0,0 +1,107 @@
+// Package main demonstrates the usage of the xstr Thai bill payment barcode functionality.
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	xstr "github.com/hotfixfirst/go-xstr"
+)
+
+func main() {
+	fmt.Println("=== Bill Barcode Examples ===")
+	fmt.Println()
+
+	// Example 1: Parse the barcode text
+	fmt.Println("1. ParseBillBarcode - Parse barcode text")
+	fmt.Println("----------------------------------------")
+
+	text := "|099400016550100\rINV001\rCUST42\r12550"
+	fmt.Printf("Text: %s\n\n", strconv.Quote(text))
+
+	barcode, err := xstr.ParseBillBarcode(text)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	fmt.Printf("  Biller ID: %s\n", barcode.BillerID)
+	fmt.Printf("  Ref1:      %s\n", barcode.Ref1)
+	fmt.Printf("  Ref2:      %s\n", barcode.Ref2)
+	fmt.Printf("  Satang:    %d\n", barcode.AmountSatang)
+	fmt.Printf("  Amount:    %s\n", barcode.Amount())
+
+	fmt.Println()
+
+	// Example 2: Build the barcode matching a bill payment QR
+	fmt.Println("2. BillBarcodeFromEMVCoQRInfo - Barcode from a QR")
+	fmt.Println("--------------------------------------------------")
+
+	qrString := "00020101021230590016A00000067701011201150994000165501000206INV0010306CUST4253037645406125.505802TH6304DF25"
+	fmt.Printf("QR String: %s\n\n", qrString)
+
+	info, err := xstr.ParseEMVCoQRString(qrString)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	fromQR, err := xstr.BillBarcodeFromEMVCoQRInfo(info)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	fmt.Printf("  Barcode: %s\n", strconv.Quote(fromQR.String()))
+
+	fmt.Println()
+
+	// Example 3: Cross-check a barcode with the QR printed next to it
+	fmt.Println("3. VerifyEMVCoQRInfo - Detect mismatched bills")
+	fmt.Println("-----------------------------------------------")
+	for _, candidate := range []string{
+		"|099400016550100\rINV001\rCUST42\r12550",
+		"|099400016550100\rINV001\rCUST42\r12500",
+		"|010555000123401\rINV002\rCUST42\r12550",
+	} {
+		scanned, err := xstr.ParseBillBarcode(candidate)
+		if err != nil {
+			fmt.Printf("  Error: %v\n", err)
+			continue
+		}
+		if err := scanned.VerifyEMVCoQRInfo(info); err != nil {
+			fmt.Printf("  %-42s -> %v\n", strconv.Quote(candidate), err)
+			continue
+		}
+		fmt.Printf("  %-42s -> matches\n", strconv.Quote(candidate))
+	}
+
+	fmt.Println()
+
+	// Example 4: Render the barcode
+	fmt.Println("4. SVG / PNG - Render as Code 128")
+	fmt.Println("---------------------------------")
+
+	svg, err := barcode.SVG(2, 60)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	png, err := barcode.PNG(2, 60)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	fmt.Printf("  SVG: %d bytes\n", len(svg))
+	fmt.Printf("  PNG: %d bytes\n", len(png))
+
+	fmt.Println()
+
+	// Example 5: Error handling
+	fmt.Println("5. Error handling")
+	fmt.Println("-----------------")
+	for _, invalid := range []string{"099400016550100\rINV001", "|12345\rINV001\r\r0"} {
+		_, err := xstr.ParseBillBarcode(invalid)
+		fmt.Printf("  Error: %v\n", err)
+	}
+
+	fmt.Println()
+	fmt.Println("=== End of Examples ===")
+}
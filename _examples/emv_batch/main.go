@@ -0,0 +1,90 @@
+// Package main demonstrates the usage of the xstr batch EMV decoding functionality.
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	xstr "github.com/hotfixfirst/go-xstr"
+)
+
+func main() {
+	fmt.Println("=== EMV Batch Examples ===")
+	fmt.Println()
+
+	ctx := context.Background()
+
+	// Example 1: Decode newline-delimited payloads
+	fmt.Println("1. NewBatchDecoder - Newline-delimited payloads")
+	fmt.Println("------------------------------------------------")
+
+	lines := strings.Join([]string{
+		"00020101021129370016A000000677010111011300668123456785802TH5303764540510.0063044ABE",
+		"010201630441C6",
+		"00020101021230590016A000000677010112011501075370008820502061234560306ABCDEF530376454031005802TH62210105INV010708TERM00016304C43B",
+		"0102015400",
+	}, "\n")
+
+	batch := xstr.NewBatchDecoder(xstr.WithBatchWorkers(2))
+	stats, err := batch.Decode(ctx, strings.NewReader(lines), func(r xstr.BatchResult) error {
+		if r.Err != nil {
+			fmt.Printf("  line %d: %s (%v)\n", r.Line, xstr.ClassifyEMVError(r.Err), r.Err)
+			return nil
+		}
+		fmt.Printf("  line %d: %s %s\n", r.Line, r.Data.QRInfo().PaymentScheme, r.Data.TransactionAmount)
+		return nil
+	})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	printStats(stats)
+
+	fmt.Println()
+
+	// Example 2: Read the payload from a CSV column
+	fmt.Println("2. WithBatchCSV - Payload from a CSV column")
+	fmt.Println("-------------------------------------------")
+
+	csv := "id,payload\n" +
+		"A1,00020101021129370016A000000677010111011300668123456785802TH5303764540510.0063044ABE\n" +
+		"A2,00020101021129370016A000000677010111021312345678901235802TH53037646304EC40\n"
+
+	batch = xstr.NewBatchDecoder(xstr.WithBatchCSV(1, true))
+	stats, err = batch.Decode(ctx, strings.NewReader(csv), func(r xstr.BatchResult) error {
+		if r.Err != nil {
+			fmt.Printf("  line %d: %v\n", r.Line, r.Err)
+			return nil
+		}
+		fmt.Printf("  line %d: %s\n", r.Line, r.Data.EMVCoQRInfo().PhoneNumber)
+		return nil
+	})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	printStats(stats)
+
+	fmt.Println()
+
+	// Example 3: Classify single decode errors
+	fmt.Println("3. ClassifyEMVError - Failure kinds")
+	fmt.Println("------------------------------------")
+	for _, qr := range []string{"010201630441C6", "0102015802TH5303764", "0102"} {
+		_, err := xstr.DecodeEMVQR(qr)
+		fmt.Printf("  %-20s -> %s\n", qr, xstr.ClassifyEMVError(err))
+	}
+
+	fmt.Println()
+	fmt.Println("=== End of Examples ===")
+}
+
+func printStats(stats *xstr.BatchStats) {
+	fmt.Println()
+	fmt.Printf("  Total:     %d\n", stats.Total)
+	fmt.Printf("  Succeeded: %d\n", stats.Succeeded)
+	fmt.Printf("  Failed:    %d\n", stats.Failed)
+	fmt.Printf("  ByScheme:  %v\n", stats.ByScheme)
+	fmt.Printf("  ByFailure: %v\n", stats.ByFailure)
+}
@@ -17,7 +17,7 @@ func main() {
 	fmt.Println("-------------------------------------------")
 
 	// Sample Thai PromptPay QR Code
-	qrString := "00020101021129370016A000000677010111011300668123456785802TH5303764540510.006304EFD4"
+	qrString := "00020101021129370016A000000677010111011300668123456785802TH5303764540510.0063044ABE"
 
 	fmt.Printf("QR String: %s\n\n", qrString)
 
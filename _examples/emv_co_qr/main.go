@@ -15,7 +15,7 @@ func main() {
 	fmt.Println("1. ParseEMVCoQRString - PromptPay with Phone Number")
 	fmt.Println("----------------------------------------------------")
 
-	qrWithPhone := "00020101021129370016A000000677010111011300668123456785802TH5303764540510.006304EFD4"
+	qrWithPhone := "00020101021129370016A000000677010111011300668123456785802TH5303764540510.0063044ABE"
 	fmt.Printf("QR String: %s\n\n", qrWithPhone)
 
 	info, err := xstr.ParseEMVCoQRString(qrWithPhone)
@@ -0,0 +1,60 @@
+// Package main demonstrates the usage of the xstr EMV consumer-presented mode QR functionality.
+package main
+
+import (
+	"fmt"
+
+	xstr "github.com/hotfixfirst/go-xstr"
+)
+
+func main() {
+	fmt.Println("=== EMV CPM Examples ===")
+	fmt.Println()
+
+	// Example 1: Decode a consumer-presented QR shown by a wallet app
+	fmt.Println("1. DecodeCPMQR - Decode a Visa CPM payload")
+	fmt.Println("-------------------------------------------")
+
+	payload := "hQVDUFYwMWFJTwegAAAAAxAQUARWSVNBVw5BEREREREREdJRIgEAD1oIQRERERERERFfIAlET0UvSk9ITiBfLQJlbp8lAhERnxAHBgEKA6AAAGQQnyYIESIzRFVmd4ifNgIAAQ=="
+	fmt.Printf("Payload: %s\n\n", payload)
+
+	cpmData, err := xstr.DecodeCPMQR(payload)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	fmt.Printf("  Format:       %s\n", cpmData.PayloadFormatIndicator)
+	fmt.Printf("  Applications: %d\n", len(cpmData.Applications))
+	app := cpmData.Applications[0]
+	fmt.Printf("  AID:          %s\n", app.AID)
+	fmt.Printf("  Label:        %s\n", app.Label)
+	fmt.Printf("  PAN:          %s\n", app.PAN)
+	fmt.Printf("  Cardholder:   %s\n", app.CardholderName)
+	fmt.Printf("  Language:     %s\n", app.LanguagePreference)
+	fmt.Printf("  Unresolved:   %v\n", app.UnresolvedData)
+
+	fmt.Println()
+
+	// Example 2: Mask card data before logging
+	fmt.Println("2. Masked - Safe for logging")
+	fmt.Println("----------------------------")
+
+	masked := cpmData.Masked().Applications[0]
+	fmt.Printf("  PAN:          %s\n", masked.PAN)
+	fmt.Printf("  Track2:       %s\n", masked.Track2)
+	fmt.Printf("  Cardholder:   %s\n", masked.CardholderName)
+	fmt.Printf("  Unresolved:   %v\n", masked.UnresolvedData)
+
+	fmt.Println()
+
+	// Example 3: Error handling
+	fmt.Println("3. Error handling")
+	fmt.Println("-----------------")
+	for _, invalid := range []string{"not base64!", "hQVDUFYwMQ=="} {
+		_, err := xstr.DecodeCPMQR(invalid)
+		fmt.Printf("  %-14s -> %v\n", invalid, err)
+	}
+
+	fmt.Println()
+	fmt.Println("=== End of Examples ===")
+}
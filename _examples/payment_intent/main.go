@@ -0,0 +1,125 @@
+// Package main demonstrates the usage of the xstr payment intent functionality.
+package main
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	xstr "github.com/hotfixfirst/go-xstr"
+)
+
+func main() {
+	fmt.Println("=== Payment Intent Examples ===")
+	fmt.Println()
+
+	// Example 1: Intent of a dynamic PromptPay QR
+	fmt.Println("1. PaymentIntent - PromptPay mobile QR")
+	fmt.Println("--------------------------------------")
+
+	qrString := "00020101021129370016A000000677010111011300668123456785802TH5303764540510.0063044ABE"
+	fmt.Printf("QR String: %s\n\n", qrString)
+
+	emvData, err := xstr.DecodeEMVQR(qrString)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	intent, err := emvData.PaymentIntent()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	printIntent(intent)
+
+	fmt.Println()
+
+	// Example 2: Static bill payment QR where the payer enters the amount
+	fmt.Println("2. Missing / SetAmount - Static bill payment QR")
+	fmt.Println("-----------------------------------------------")
+
+	qrString = "00020101021130590016A00000067701011201150994000165501000206INV0010306CUST4253037645802TH63047767"
+	fmt.Printf("QR String: %s\n\n", qrString)
+
+	info, err := xstr.ParseEMVCoQRString(qrString)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	intent, err = info.PaymentIntent()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	fmt.Printf("  Missing:    %v\n", intent.Missing())
+	fmt.Printf("  Validate:   %v\n", intent.Validate())
+	if slices.Contains(intent.Missing(), xstr.PaymentIntentFieldAmount) {
+		if err := intent.SetAmount("125.50"); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+	}
+	printIntent(intent)
+
+	fmt.Println()
+
+	// Example 3: Intent of a UPI payment link
+	fmt.Println("3. ParseUPILink - UPI payment link")
+	fmt.Println("----------------------------------")
+
+	link := "upi://pay?pa=shop@okbank&pn=Chai%20Point&am=10.50&cu=INR&tr=ORD123"
+	fmt.Printf("Link: %s\n\n", link)
+
+	upi, err := xstr.ParseUPILink(link)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	var source xstr.PaymentIntentSource = upi
+	intent, err = source.PaymentIntent()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	printIntent(intent)
+
+	fmt.Println()
+
+	// Example 4: Expiry read with a Thai bank parser
+	fmt.Println("4. PaymentIntentWithExpiry - Custom expiry parser")
+	fmt.Println("-------------------------------------------------")
+
+	qrString = "00020101021229370016A00000067701011101130066812345678530376454031005802TH621809142026123123595963041AF1"
+	fmt.Printf("QR String: %s\n\n", qrString)
+
+	emvData, err = xstr.DecodeEMVQR(qrString)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	intent, err = emvData.PaymentIntentWithExpiry(xstr.ThaiBankExpiryParser("09", "20060102150405"))
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	fmt.Printf("  Expires At: %s\n", intent.ExpiresAt.Format(time.RFC3339))
+
+	fmt.Println()
+	fmt.Println("=== End of Examples ===")
+}
+
+func printIntent(intent *xstr.PaymentIntent) {
+	fmt.Printf("  Scheme:     %s\n", intent.Scheme)
+	fmt.Printf("  Payee:      %s (%s)\n", intent.PayeeID, intent.PayeeType)
+	if intent.PayeeName != "" {
+		fmt.Printf("  Payee Name: %s\n", intent.PayeeName)
+	}
+	fmt.Printf("  Amount:     %d %s\n", intent.AmountMinor, intent.Currency)
+	if intent.Reference1 != "" {
+		fmt.Printf("  Reference1: %s\n", intent.Reference1)
+	}
+	if intent.Reference2 != "" {
+		fmt.Printf("  Reference2: %s\n", intent.Reference2)
+	}
+	fmt.Printf("  POI Method: %s\n", intent.POIMethodType)
+}
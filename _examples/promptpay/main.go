@@ -0,0 +1,72 @@
+// Package main demonstrates the usage of the xstr PromptPay proxy identification functionality.
+package main
+
+import (
+	"fmt"
+
+	xstr "github.com/hotfixfirst/go-xstr"
+)
+
+func main() {
+	fmt.Println("=== PromptPay Examples ===")
+	fmt.Println()
+
+	// Example 1: Extract the proxy of a decoded QR
+	fmt.Println("1. PromptPayProxy - Classify the tag 29 proxy")
+	fmt.Println("----------------------------------------------")
+
+	qrString := "00020101021129370016A000000677010111011300668123456785802TH5303764540510.0063044ABE"
+	fmt.Printf("QR String: %s\n\n", qrString)
+
+	emvData, err := xstr.DecodeEMVQR(qrString)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	proxy, err := emvData.PromptPayProxy()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	fmt.Printf("  Type:    %s\n", proxy.Type)
+	fmt.Printf("  Sub-tag: %s\n", proxy.SubTag)
+	fmt.Printf("  Value:   %s\n", proxy.Value)
+	fmt.Printf("  E.164:   %s\n", proxy.E164)
+	fmt.Printf("  Masked:  %s\n", proxy.Masked())
+
+	fmt.Println()
+
+	// Example 2: Classify proxy values by sub-tag
+	fmt.Println("2. ParsePromptPayProxy - Validate proxy values")
+	fmt.Println("-----------------------------------------------")
+	proxies := []struct {
+		subTag string
+		value  string
+	}{
+		{"01", "0066812345678"},
+		{"02", "1101700203450"},
+		{"02", "1101700203451"},
+		{"03", "123456789012345"},
+		{"04", "1234567890"},
+	}
+	for _, p := range proxies {
+		parsed, err := xstr.ParsePromptPayProxy(p.subTag, p.value)
+		if err != nil {
+			fmt.Printf("  %s %-16s -> Error: %v\n", p.subTag, p.value, err)
+			continue
+		}
+		fmt.Printf("  %s %-16s -> %s (%s)\n", p.subTag, p.value, parsed.Type, parsed.Masked())
+	}
+
+	fmt.Println()
+
+	// Example 3: Thai national ID checksum
+	fmt.Println("3. IsValidThaiNationalID - Checksum validation")
+	fmt.Println("-----------------------------------------------")
+	for _, id := range []string{"1101700203450", "1101700203451", "12345"} {
+		fmt.Printf("  %-14s -> %v\n", id, xstr.IsValidThaiNationalID(id))
+	}
+
+	fmt.Println()
+	fmt.Println("=== End of Examples ===")
+}
@@ -0,0 +1,77 @@
+// Package main demonstrates the usage of the xstr Thai bank slip QR functionality.
+package main
+
+import (
+	"fmt"
+
+	xstr "github.com/hotfixfirst/go-xstr"
+)
+
+func main() {
+	fmt.Println("=== Thai Slip QR Examples ===")
+	fmt.Println()
+
+	// Example 1: Decode the mini-QR of a transfer slip
+	fmt.Println("1. DecodeSlipQR - Decode a KBANK slip")
+	fmt.Println("--------------------------------------")
+
+	qrString := "00370006000001010300402162023051912345ABC5102TH910484C3"
+	fmt.Printf("QR String: %s\n\n", qrString)
+
+	slip, err := xstr.DecodeSlipQR(qrString)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	fmt.Printf("  API ID:       %s\n", slip.APIID)
+	fmt.Printf("  Sending Bank: %s\n", slip.SendingBank)
+	fmt.Printf("  Trans Ref:    %s\n", slip.TransRef)
+	fmt.Printf("  Country Code: %s\n", slip.CountryCode)
+	fmt.Printf("  CRC:          %s\n", slip.CRC)
+	if slip.Bank != nil {
+		fmt.Printf("  Bank:         %s (%s)\n", slip.Bank.Name, slip.Bank.Abbreviation)
+	}
+
+	fmt.Println()
+
+	// Example 2: Tell slip QRs from payment QRs
+	fmt.Println("2. IsSlipQR - Detect slip QRs")
+	fmt.Println("-----------------------------")
+	candidates := []string{
+		"00370006000001010300402162023051912345ABC5102TH910484C3",
+		"00020101021129370016A000000677010111011300668123456785802TH5303764540510.0063044ABE",
+	}
+	for _, candidate := range candidates {
+		fmt.Printf("  %.30s... -> %v\n", candidate, xstr.IsSlipQR(candidate))
+	}
+
+	fmt.Println()
+
+	// Example 3: Offline bank lookup
+	fmt.Println("3. LookupThaiBank - Bank code lookup")
+	fmt.Println("------------------------------------")
+	for _, code := range []string{"002", "014", "999"} {
+		bank, found := xstr.LookupThaiBank(code)
+		if !found {
+			fmt.Printf("  %s -> unknown\n", code)
+			continue
+		}
+		fmt.Printf("  %s -> %s (%s)\n", code, bank.Abbreviation, bank.Name)
+	}
+
+	fmt.Println()
+
+	// Example 4: Error handling
+	fmt.Println("4. Error handling")
+	fmt.Println("-----------------")
+	for _, invalid := range []string{
+		"00370006000001010300402162023051912345ABC5102TH910484C4",
+		"0025000600000101039990204REF15102TH",
+	} {
+		_, err := xstr.DecodeSlipQR(invalid)
+		fmt.Printf("  Error: %v\n", err)
+	}
+
+	fmt.Println()
+	fmt.Println("=== End of Examples ===")
+}
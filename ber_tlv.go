@@ -0,0 +1,321 @@
+package xstr
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Common BER-TLV errors.
+var (
+	ErrBERInvalidEncoding = errors.New("invalid BER-TLV: invalid encoding")
+	ErrBERInvalidTag      = errors.New("invalid BER-TLV: invalid tag")
+)
+
+// berMaxDepth bounds the nesting of constructed tags.
+const berMaxDepth = 16
+
+// BERTLV is a node of a parsed BER-TLV tree.
+// Tags are uppercase hexadecimal strings such as "5A" or "9F26".
+type BERTLV struct {
+	Tag      string   `json:"tag"`
+	Value    []byte   `json:"value"`              // Raw value; for constructed tags the encoded children
+	Children []BERTLV `json:"children,omitempty"` // Parsed children of constructed tags
+}
+
+// Constructed reports whether the tag is constructed (bit 6 of the first tag byte).
+func (t BERTLV) Constructed() bool {
+	first, err := hex.DecodeString(t.Tag[:min(2, len(t.Tag))])
+	return err == nil && len(first) == 1 && first[0]&0x20 != 0
+}
+
+// Name returns the EMV dictionary name of the tag, or an empty string if unknown.
+func (t BERTLV) Name() string {
+	return BERTagName(t.Tag)
+}
+
+// Find returns the first node with the given tag, searching depth-first.
+func (t BERTLV) Find(tag string) (*BERTLV, bool) {
+	return FindBERTLV(t.Children, tag)
+}
+
+// FindBERTLV returns the first node with the given tag, searching nodes depth-first.
+// The tag is matched case-insensitively.
+func FindBERTLV(nodes []BERTLV, tag string) (*BERTLV, bool) {
+	for i := range nodes {
+		if strings.EqualFold(nodes[i].Tag, tag) {
+			return &nodes[i], true
+		}
+		if found, ok := FindBERTLV(nodes[i].Children, tag); ok {
+			return found, true
+		}
+	}
+	return nil, false
+}
+
+// ParseBERTLV parses BER-TLV data into a tree, supporting multi-byte tags,
+// long-form lengths (up to 4 length bytes) and constructed tags. Values are
+// sub-slices of data. Errors wrap ErrEMVInvalidLength, ErrEMVInvalidData or
+// ErrEMVTrailingData like the MPM parser.
+func ParseBERTLV(data []byte) ([]BERTLV, error) {
+	return parseBERTLV(data, 0, 0)
+}
+
+// ParseBERTLVHex parses hexadecimal BER-TLV data, ignoring spaces.
+//
+// Examples:
+//   - ParseBERTLVHex("5A 08 4111111111111111") -> [{Tag: "5A", Value: 0x4111...}]
+func ParseBERTLVHex(s string) ([]BERTLV, error) {
+	data, err := hex.DecodeString(strings.ReplaceAll(s, " ", ""))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBERInvalidEncoding, err)
+	}
+	return ParseBERTLV(data)
+}
+
+// ParseBERTLVBase64 parses base64 BER-TLV data, with or without padding.
+func ParseBERTLVBase64(s string) ([]BERTLV, error) {
+	data, err := decodeBase64(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBERInvalidEncoding, err)
+	}
+	return ParseBERTLV(data)
+}
+
+// EncodeBERTLV encodes a tree into BER-TLV data using minimal length encoding.
+// Constructed nodes with children are encoded from their children; other nodes from Value.
+func EncodeBERTLV(nodes []BERTLV) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeBERTLV(&buf, nodes, 0); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// EncodeBERTLVHex encodes a tree into uppercase hexadecimal BER-TLV data.
+func EncodeBERTLVHex(nodes []BERTLV) (string, error) {
+	data, err := EncodeBERTLV(nodes)
+	if err != nil {
+		return "", err
+	}
+	return strings.ToUpper(hex.EncodeToString(data)), nil
+}
+
+// EncodeBERTLVBase64 encodes a tree into padded base64 BER-TLV data.
+func EncodeBERTLVBase64(nodes []BERTLV) (string, error) {
+	data, err := EncodeBERTLV(nodes)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// parseBERTLV parses data located at offset within the outermost input.
+func parseBERTLV(data []byte, offset, depth int) ([]BERTLV, error) {
+	if depth > berMaxDepth {
+		return nil, fmt.Errorf("%w at offset %d: nesting too deep", ErrEMVInvalidData, offset)
+	}
+
+	var nodes []BERTLV
+	position := 0
+	for position < len(data) {
+		start := position
+
+		// Tag: low 5 bits all set means more tag bytes follow while bit 8 is set
+		constructed := data[position]&0x20 != 0
+		position++
+		if data[start]&0x1F == 0x1F {
+			for position < len(data) && data[position]&0x80 != 0 {
+				position++
+			}
+			position++
+		}
+		if position >= len(data) {
+			return nil, fmt.Errorf("%w at offset %d", ErrEMVTrailingData, offset+start)
+		}
+		tag := strings.ToUpper(hex.EncodeToString(data[start:position]))
+
+		// Length: short form below 0x80, otherwise 0x81-0x84 count the length bytes
+		length := int(data[position])
+		position++
+		if length&0x80 != 0 {
+			count := length & 0x7F
+			if count == 0 || count > 4 || position+count > len(data) {
+				return nil, fmt.Errorf("%w at offset %d: tag %s", ErrEMVInvalidLength, offset+start, tag)
+			}
+			length = 0
+			for _, b := range data[position : position+count] {
+				length = length<<8 | int(b)
+			}
+			position += count
+		}
+		if length < 0 || length > len(data)-position {
+			return nil, fmt.Errorf("%w at tag %s", ErrEMVInvalidData, tag)
+		}
+
+		node := BERTLV{Tag: tag, Value: data[position : position+length]}
+		if constructed {
+			children, err := parseBERTLV(node.Value, offset+position, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			node.Children = children
+		}
+		nodes = append(nodes, node)
+		position += length
+	}
+	return nodes, nil
+}
+
+// writeBERTLV encodes nodes into buf.
+func writeBERTLV(buf *bytes.Buffer, nodes []BERTLV, depth int) error {
+	if depth > berMaxDepth {
+		return fmt.Errorf("%w: nesting too deep", ErrBERInvalidTag)
+	}
+
+	for _, node := range nodes {
+		tag, err := hex.DecodeString(node.Tag)
+		if err != nil || !validBERTag(tag) {
+			return fmt.Errorf("%w: %q", ErrBERInvalidTag, node.Tag)
+		}
+
+		value := node.Value
+		if node.Children != nil {
+			if tag[0]&0x20 == 0 {
+				return fmt.Errorf("%w: primitive tag %s has children", ErrBERInvalidTag, node.Tag)
+			}
+			var children bytes.Buffer
+			if err := writeBERTLV(&children, node.Children, depth+1); err != nil {
+				return err
+			}
+			value = children.Bytes()
+		}
+
+		buf.Write(tag)
+		writeBERLength(buf, len(value))
+		buf.Write(value)
+	}
+	return nil
+}
+
+// writeBERLength writes a length in minimal short or long form.
+func writeBERLength(buf *bytes.Buffer, length int) {
+	if length < 0x80 {
+		buf.WriteByte(byte(length))
+		return
+	}
+	var lengthBytes []byte
+	for n := length; n > 0; n >>= 8 {
+		lengthBytes = append([]byte{byte(n)}, lengthBytes...)
+	}
+	buf.WriteByte(0x80 | byte(len(lengthBytes)))
+	buf.Write(lengthBytes)
+}
+
+// validBERTag reports whether tag is a single complete BER tag.
+func validBERTag(tag []byte) bool {
+	switch {
+	case len(tag) == 0:
+		return false
+	case tag[0]&0x1F != 0x1F:
+		return len(tag) == 1
+	case len(tag) == 1:
+		return false
+	}
+	for _, b := range tag[1 : len(tag)-1] {
+		if b&0x80 == 0 {
+			return false
+		}
+	}
+	return tag[len(tag)-1]&0x80 == 0
+}
+
+// decodeBase64 decodes padded or unpadded standard base64.
+func decodeBase64(s string) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return base64.RawStdEncoding.DecodeString(s)
+	}
+	return data, nil
+}
+
+// berTagNames is a dictionary of common EMV BER-TLV tags.
+var berTagNames = map[string]string{
+	"42":   "Issuer Identification Number",
+	"4F":   "Application Identifier (ADF Name)",
+	"50":   "Application Label",
+	"57":   "Track 2 Equivalent Data",
+	"5A":   "Application PAN",
+	"5F20": "Cardholder Name",
+	"5F24": "Application Expiration Date",
+	"5F25": "Application Effective Date",
+	"5F28": "Issuer Country Code",
+	"5F2A": "Transaction Currency Code",
+	"5F2D": "Language Preference",
+	"5F34": "PAN Sequence Number",
+	"5F50": "Issuer URL",
+	"61":   "Application Template",
+	"62":   "Common Data Transparent Template",
+	"63":   "Application Specific Transparent Template",
+	"64":   "Common Data Template",
+	"6F":   "File Control Information Template",
+	"70":   "Record Template",
+	"77":   "Response Message Template Format 2",
+	"80":   "Response Message Template Format 1",
+	"82":   "Application Interchange Profile",
+	"84":   "Dedicated File Name",
+	"85":   "Payload Format Indicator",
+	"87":   "Application Priority Indicator",
+	"8C":   "CDOL1",
+	"8D":   "CDOL2",
+	"8E":   "CVM List",
+	"8F":   "Certification Authority Public Key Index",
+	"90":   "Issuer Public Key Certificate",
+	"94":   "Application File Locator",
+	"95":   "Terminal Verification Results",
+	"9A":   "Transaction Date",
+	"9B":   "Transaction Status Information",
+	"9C":   "Transaction Type",
+	"9F02": "Amount, Authorised",
+	"9F03": "Amount, Other",
+	"9F06": "Application Identifier (Terminal)",
+	"9F07": "Application Usage Control",
+	"9F08": "Application Version Number",
+	"9F09": "Application Version Number (Terminal)",
+	"9F0D": "Issuer Action Code - Default",
+	"9F0E": "Issuer Action Code - Denial",
+	"9F0F": "Issuer Action Code - Online",
+	"9F10": "Issuer Application Data",
+	"9F12": "Application Preferred Name",
+	"9F19": "Token Requestor ID",
+	"9F1A": "Terminal Country Code",
+	"9F1E": "Interface Device Serial Number",
+	"9F21": "Transaction Time",
+	"9F24": "Payment Account Reference",
+	"9F25": "Last 4 Digits of PAN",
+	"9F26": "Application Cryptogram",
+	"9F27": "Cryptogram Information Data",
+	"9F33": "Terminal Capabilities",
+	"9F34": "CVM Results",
+	"9F35": "Terminal Type",
+	"9F36": "Application Transaction Counter",
+	"9F37": "Unpredictable Number",
+	"9F38": "PDOL",
+	"9F41": "Transaction Sequence Counter",
+	"9F4C": "ICC Dynamic Number",
+	"9F6E": "Form Factor Indicator",
+	"A5":   "FCI Proprietary Template",
+	"BF0C": "FCI Issuer Discretionary Data",
+}
+
+// BERTagName returns the EMV name of a BER-TLV tag, or an empty string if unknown.
+//
+// Examples:
+//   - BERTagName("9F26") -> "Application Cryptogram"
+//   - BERTagName("5a") -> "Application PAN"
+func BERTagName(tag string) string {
+	return berTagNames[strings.ToUpper(tag)]
+}
@@ -0,0 +1,184 @@
+package xstr
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBERTLVHex(t *testing.T) {
+	nodes, err := ParseBERTLVHex("6F 10 84 07 A0000000031010 A5 05 9F38 02 9F1A 5A 08 4111111111111111")
+	require.NoError(t, err)
+	require.Len(t, nodes, 2)
+
+	fci := nodes[0]
+	assert.Equal(t, "6F", fci.Tag)
+	assert.True(t, fci.Constructed())
+	assert.Equal(t, "File Control Information Template", fci.Name())
+	require.Len(t, fci.Children, 2)
+	assert.Equal(t, "84", fci.Children[0].Tag)
+	assert.Equal(t, []byte{0xA0, 0x00, 0x00, 0x00, 0x03, 0x10, 0x10}, fci.Children[0].Value)
+	assert.False(t, fci.Children[0].Constructed())
+
+	pdol, ok := fci.Find("9F38")
+	require.True(t, ok)
+	assert.Equal(t, []byte{0x9F, 0x1A}, pdol.Value)
+
+	pan, ok := FindBERTLV(nodes, "5a")
+	require.True(t, ok)
+	assert.Equal(t, "5A", pan.Tag)
+	assert.Nil(t, pan.Children)
+
+	_, ok = FindBERTLV(nodes, "9F26")
+	assert.False(t, ok)
+}
+
+func TestParseBERTLV_LongFormLength(t *testing.T) {
+	value := bytes.Repeat([]byte{0xAB}, 300)
+	data := append([]byte{0x9F, 0x10, 0x82, 0x01, 0x2C}, value...)
+
+	nodes, err := ParseBERTLV(data)
+	require.NoError(t, err)
+	require.Len(t, nodes, 1)
+	assert.Equal(t, "9F10", nodes[0].Tag)
+	assert.Equal(t, value, nodes[0].Value)
+
+	encoded, err := EncodeBERTLV(nodes)
+	require.NoError(t, err)
+	assert.Equal(t, data, encoded)
+}
+
+func TestParseBERTLV_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		hex     string
+		wantErr error
+	}{
+		{name: "missing length", hex: "5A", wantErr: ErrEMVTrailingData},
+		{name: "truncated multi-byte tag", hex: "9F", wantErr: ErrEMVTrailingData},
+		{name: "indefinite length", hex: "6180", wantErr: ErrEMVInvalidLength},
+		{name: "too many length bytes", hex: "5A85FFFFFFFFFF", wantErr: ErrEMVInvalidLength},
+		{name: "truncated long-form length", hex: "5A82FF", wantErr: ErrEMVInvalidLength},
+		{name: "value longer than data", hex: "5A0841", wantErr: ErrEMVInvalidData},
+		{name: "invalid child", hex: "61025A08", wantErr: ErrEMVInvalidData},
+		{name: "invalid hex", hex: "ZZ", wantErr: ErrBERInvalidEncoding},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseBERTLVHex(tt.hex)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestParseBERTLV_NestingTooDeep(t *testing.T) {
+	data := []byte{}
+	for i := 0; i <= berMaxDepth+1; i++ {
+		data = append([]byte{0x61, byte(len(data))}, data...)
+	}
+
+	_, err := ParseBERTLV(data)
+	assert.ErrorIs(t, err, ErrEMVInvalidData)
+}
+
+func TestEncodeBERTLV(t *testing.T) {
+	tests := []struct {
+		name     string
+		nodes    []BERTLV
+		expected string
+		wantErr  error
+	}{
+		{
+			name:     "primitive",
+			nodes:    []BERTLV{{Tag: "5A", Value: []byte{0x41, 0x11}}},
+			expected: "5A024111",
+		},
+		{
+			name: "constructed from children",
+			nodes: []BERTLV{{Tag: "61", Children: []BERTLV{
+				{Tag: "4F", Value: []byte{0xA0}},
+				{Tag: "9F25", Value: []byte{0x11, 0x11}},
+			}}},
+			expected: "61084F01A09F25021111",
+		},
+		{
+			name:     "lowercase tag",
+			nodes:    []BERTLV{{Tag: "9f36", Value: []byte{0x00, 0x01}}},
+			expected: "9F36020001",
+		},
+		{
+			name:    "invalid hex tag",
+			nodes:   []BERTLV{{Tag: "XY"}},
+			wantErr: ErrBERInvalidTag,
+		},
+		{
+			name:    "incomplete multi-byte tag",
+			nodes:   []BERTLV{{Tag: "9F"}},
+			wantErr: ErrBERInvalidTag,
+		},
+		{
+			name:    "single-byte tag with trailing bytes",
+			nodes:   []BERTLV{{Tag: "5A5A"}},
+			wantErr: ErrBERInvalidTag,
+		},
+		{
+			name:    "primitive tag with children",
+			nodes:   []BERTLV{{Tag: "5A", Children: []BERTLV{{Tag: "4F"}}}},
+			wantErr: ErrBERInvalidTag,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := EncodeBERTLVHex(tt.nodes)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestBERTLV_Base64RoundTrip(t *testing.T) {
+	nodes, err := ParseBERTLVBase64(cpmVisaQR)
+	require.NoError(t, err)
+
+	encoded, err := EncodeBERTLVBase64(nodes)
+	require.NoError(t, err)
+	assert.Equal(t, cpmVisaQR, encoded)
+
+	_, err = ParseBERTLVBase64("not base64!")
+	assert.ErrorIs(t, err, ErrBERInvalidEncoding)
+}
+
+func TestBERTagName(t *testing.T) {
+	assert.Equal(t, "Application Cryptogram", BERTagName("9F26"))
+	assert.Equal(t, "Application PAN", BERTagName("5a"))
+	assert.Equal(t, "Payload Format Indicator", BERTagName("85"))
+	assert.Empty(t, BERTagName("DF01"))
+}
+
+func FuzzParseBERTLV(f *testing.F) {
+	f.Add([]byte{0x6F, 0x05, 0x84, 0x03, 0xA0, 0x00, 0x00})
+	f.Add([]byte{0x9F, 0x10, 0x81, 0x01, 0xFF})
+	f.Add([]byte{0x61, 0x80})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		nodes, err := ParseBERTLV(data)
+		if err != nil {
+			return
+		}
+		encoded, err := EncodeBERTLV(nodes)
+		if err != nil {
+			t.Fatalf("parsed tree does not encode: %v", err)
+		}
+		if _, err := ParseBERTLV(encoded); err != nil {
+			t.Fatalf("re-encoded data does not parse: %v", err)
+		}
+	})
+}
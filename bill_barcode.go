@@ -0,0 +1,175 @@
+package xstr
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Thai bill payment barcode limits.
+const (
+	BillBarcodeBillerIDLength = 15 // 13-digit tax ID followed by a 2-digit suffix
+	BillBarcodeMaxRefLength   = 20
+)
+
+const (
+	billBarcodePrefix         = "|"
+	billBarcodeFieldSeparator = "\r"
+	billBarcodeCurrency       = "764" // THB, amounts are in satang
+)
+
+// Thai bill payment barcode errors.
+var (
+	ErrInvalidBillBarcode  = errors.New("invalid bill payment barcode")
+	ErrBillBarcodeMismatch = errors.New("bill payment barcode does not match QR")
+)
+
+// BillBarcode represents the text of a Thai bill payment Code 128 barcode:
+// "|{BillerID}\r{Ref1}\r{Ref2}\r{Amount in satang}".
+type BillBarcode struct {
+	BillerID     string `json:"biller_id"` // 15-digit biller ID (tax ID + suffix)
+	Ref1         string `json:"ref1"`
+	Ref2         string `json:"ref2"`
+	AmountSatang int64  `json:"amount_satang"` // 0 when the payer enters the amount
+}
+
+// ParseBillBarcode parses the text read from a Thai bill payment barcode.
+//
+// Examples:
+//   - ParseBillBarcode("|099400016550100\rINV001\r\r12550") -> {BillerID: "099400016550100", Ref1: "INV001", AmountSatang: 12550}
+//   - ParseBillBarcode("099400016550100") -> nil, ErrInvalidBillBarcode
+func ParseBillBarcode(text string) (*BillBarcode, error) {
+	if !strings.HasPrefix(text, billBarcodePrefix) {
+		return nil, fmt.Errorf("%w: missing %q prefix", ErrInvalidBillBarcode, billBarcodePrefix)
+	}
+
+	parts := strings.Split(text[len(billBarcodePrefix):], billBarcodeFieldSeparator)
+	if len(parts) != 4 {
+		return nil, fmt.Errorf("%w: expected 4 fields, got %d", ErrInvalidBillBarcode, len(parts))
+	}
+	if !isDigits(parts[3]) || parts[3] == "" {
+		return nil, fmt.Errorf("%w: amount %q must be digits", ErrInvalidBillBarcode, parts[3])
+	}
+	amount, err := strconv.ParseInt(parts[3], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: amount %q: %v", ErrInvalidBillBarcode, parts[3], err)
+	}
+
+	barcode := &BillBarcode{BillerID: parts[0], Ref1: parts[1], Ref2: parts[2], AmountSatang: amount}
+	if err := barcode.Validate(); err != nil {
+		return nil, err
+	}
+	return barcode, nil
+}
+
+// BillBarcodeFromEMVCoQRInfo builds the barcode matching a PromptPay bill payment QR (tag 30).
+func BillBarcodeFromEMVCoQRInfo(info *EMVCoQRInfo) (*BillBarcode, error) {
+	amount, err := billBarcodeAmount(info)
+	if err != nil {
+		return nil, err
+	}
+
+	barcode := &BillBarcode{BillerID: info.BillerID, Ref1: info.Ref1, Ref2: info.Ref2, AmountSatang: amount}
+	if err := barcode.Validate(); err != nil {
+		return nil, err
+	}
+	return barcode, nil
+}
+
+// Validate checks the biller ID, references and amount.
+// References must be printable ASCII and at most 20 characters; Ref1 is required.
+func (b *BillBarcode) Validate() error {
+	switch {
+	case len(b.BillerID) != BillBarcodeBillerIDLength || !isDigits(b.BillerID):
+		return fmt.Errorf("%w: biller ID %q must be %d digits", ErrInvalidBillBarcode, b.BillerID, BillBarcodeBillerIDLength)
+	case b.Ref1 == "":
+		return fmt.Errorf("%w: missing Ref1", ErrInvalidBillBarcode)
+	case b.AmountSatang < 0:
+		return fmt.Errorf("%w: negative amount %d", ErrInvalidBillBarcode, b.AmountSatang)
+	}
+	for i, ref := range []string{b.Ref1, b.Ref2} {
+		if len(ref) > BillBarcodeMaxRefLength || !isPrintableASCII(ref) {
+			return fmt.Errorf("%w: Ref%d %q must be up to %d printable ASCII characters", ErrInvalidBillBarcode, i+1, ref, BillBarcodeMaxRefLength)
+		}
+	}
+	return nil
+}
+
+// String returns the barcode text.
+func (b *BillBarcode) String() string {
+	return billBarcodePrefix + strings.Join([]string{b.BillerID, b.Ref1, b.Ref2, strconv.FormatInt(b.AmountSatang, 10)}, billBarcodeFieldSeparator)
+}
+
+// Amount returns the amount in EMV form, e.g. "125.50", or "" when no amount is set.
+func (b *BillBarcode) Amount() string {
+	if b.AmountSatang == 0 {
+		return ""
+	}
+	return formatEMVAmountMinorUnits(b.AmountSatang, iso4217Currencies[billBarcodeCurrency].MinorUnits)
+}
+
+// VerifyEMVCoQRInfo cross-validates the barcode against a PromptPay bill payment QR printed
+// on the same bill. Biller ID and references are compared exactly and amounts numerically.
+// A mismatch error wraps ErrBillBarcodeMismatch and lists the differing fields.
+func (b *BillBarcode) VerifyEMVCoQRInfo(info *EMVCoQRInfo) error {
+	var mismatches []string
+	if b.BillerID != info.BillerID {
+		mismatches = append(mismatches, "BillerID")
+	}
+	if b.Ref1 != info.Ref1 {
+		mismatches = append(mismatches, "Ref1")
+	}
+	if b.Ref2 != info.Ref2 {
+		mismatches = append(mismatches, "Ref2")
+	}
+	if amount, err := billBarcodeAmount(info); err != nil || amount != b.AmountSatang {
+		mismatches = append(mismatches, "Amount")
+	}
+
+	if len(mismatches) > 0 {
+		return fmt.Errorf("%w: %s", ErrBillBarcodeMismatch, strings.Join(mismatches, ", "))
+	}
+	return nil
+}
+
+// PNG renders the barcode as a Code 128 PNG image. See RenderCode128PNG.
+func (b *BillBarcode) PNG(moduleWidth, height int) ([]byte, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	return RenderCode128PNG(b.String(), moduleWidth, height)
+}
+
+// SVG renders the barcode as a Code 128 SVG document. See RenderCode128SVG.
+func (b *BillBarcode) SVG(moduleWidth, height int) (string, error) {
+	if err := b.Validate(); err != nil {
+		return "", err
+	}
+	return RenderCode128SVG(b.String(), moduleWidth, height)
+}
+
+// billBarcodeAmount converts the QR amount to satang. QRs without an amount map to 0.
+func billBarcodeAmount(info *EMVCoQRInfo) (int64, error) {
+	if info.Amount == "" {
+		return 0, nil
+	}
+	if info.CurrencyISO4217 != "" && info.CurrencyISO4217 != billBarcodeCurrency {
+		return 0, fmt.Errorf("%w: currency %s is not THB", ErrInvalidBillBarcode, info.CurrencyISO4217)
+	}
+	amount, err := parseEMVAmountMinorUnits(info.Amount, iso4217Currencies[billBarcodeCurrency].MinorUnits)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidBillBarcode, err)
+	}
+	return amount, nil
+}
+
+// isPrintableASCII reports whether s consists of printable ASCII characters only.
+func isPrintableASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 0x20 || s[i] > 0x7E {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,144 @@
+package xstr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	billDynamicQR = "00020101021230590016A00000067701011201150994000165501000206INV0010306CUST4253037645406125.505802TH6304DF25"
+	billStaticQR  = "00020101021130590016A00000067701011201150994000165501000206INV0010306CUST4253037645802TH63047767"
+	billBarcode   = "|099400016550100\rINV001\rCUST42\r12550"
+)
+
+func TestParseBillBarcode(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected *BillBarcode
+		wantErr  bool
+	}{
+		{
+			name:     "with amount",
+			text:     billBarcode,
+			expected: &BillBarcode{BillerID: "099400016550100", Ref1: "INV001", Ref2: "CUST42", AmountSatang: 12550},
+		},
+		{
+			name:     "without Ref2 and amount",
+			text:     "|099400016550100\rINV001\r\r0",
+			expected: &BillBarcode{BillerID: "099400016550100", Ref1: "INV001"},
+		},
+		{name: "missing prefix", text: "099400016550100\rINV001\r\r0", wantErr: true},
+		{name: "missing field", text: "|099400016550100\rINV001\r0", wantErr: true},
+		{name: "short biller ID", text: "|0994000165501\rINV001\r\r0", wantErr: true},
+		{name: "missing Ref1", text: "|099400016550100\r\r\r0", wantErr: true},
+		{name: "Ref1 too long", text: "|099400016550100\rINV0012345678901234567\r\r0", wantErr: true},
+		{name: "decimal amount", text: "|099400016550100\rINV001\r\r125.50", wantErr: true},
+		{name: "empty amount", text: "|099400016550100\rINV001\r\r", wantErr: true},
+		{name: "amount overflow", text: "|099400016550100\rINV001\r\r99999999999999999999", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseBillBarcode(tt.text)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidBillBarcode)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+			assert.Equal(t, tt.text, result.String())
+		})
+	}
+}
+
+func TestBillBarcode_Amount(t *testing.T) {
+	assert.Equal(t, "125.50", (&BillBarcode{AmountSatang: 12550}).Amount())
+	assert.Equal(t, "0.05", (&BillBarcode{AmountSatang: 5}).Amount())
+	assert.Equal(t, "", (&BillBarcode{}).Amount())
+}
+
+func TestBillBarcodeFromEMVCoQRInfo(t *testing.T) {
+	tests := []struct {
+		name     string
+		qrString string
+		expected string
+	}{
+		{name: "dynamic bill QR", qrString: billDynamicQR, expected: billBarcode},
+		{name: "static bill QR", qrString: billStaticQR, expected: "|099400016550100\rINV001\rCUST42\r0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := ParseEMVCoQRString(tt.qrString)
+			require.NoError(t, err)
+
+			barcode, err := BillBarcodeFromEMVCoQRInfo(info)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, barcode.String())
+			assert.NoError(t, barcode.VerifyEMVCoQRInfo(info))
+		})
+	}
+
+	info, err := ParseEMVCoQRString(staticPromptPayQR)
+	require.NoError(t, err)
+	_, err = BillBarcodeFromEMVCoQRInfo(info)
+	assert.ErrorIs(t, err, ErrInvalidBillBarcode)
+}
+
+func TestBillBarcode_VerifyEMVCoQRInfo(t *testing.T) {
+	info, err := ParseEMVCoQRString(billDynamicQR)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		text    string
+		wantErr string
+	}{
+		{name: "matches", text: billBarcode},
+		{
+			name:    "amount differs",
+			text:    "|099400016550100\rINV001\rCUST42\r12500",
+			wantErr: "bill payment barcode does not match QR: Amount",
+		},
+		{
+			name:    "biller and reference differ",
+			text:    "|010555000123401\rINV002\rCUST42\r12550",
+			wantErr: "bill payment barcode does not match QR: BillerID, Ref1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			barcode, err := ParseBillBarcode(tt.text)
+			require.NoError(t, err)
+
+			err = barcode.VerifyEMVCoQRInfo(info)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrBillBarcodeMismatch)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestBillBarcode_Render(t *testing.T) {
+	barcode, err := ParseBillBarcode(billBarcode)
+	require.NoError(t, err)
+
+	png, err := barcode.PNG(2, 50)
+	require.NoError(t, err)
+	assert.Equal(t, "\x89PNG", string(png[:4]))
+
+	svg, err := barcode.SVG(2, 50)
+	require.NoError(t, err)
+	assert.Contains(t, svg, `<svg xmlns="http://www.w3.org/2000/svg"`)
+
+	_, err = (&BillBarcode{BillerID: "1"}).SVG(2, 50)
+	assert.ErrorIs(t, err, ErrInvalidBillBarcode)
+}
@@ -0,0 +1,200 @@
+package xstr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+)
+
+// Code 128 symbol values for start, stop and code set switches.
+const (
+	code128CodeC  = 99
+	code128CodeB  = 100
+	code128CodeA  = 101
+	code128StartA = 103
+	code128StartB = 104
+	code128StartC = 105
+	code128Stop   = 106
+
+	// code128QuietZone is the number of blank modules rendered on each side.
+	code128QuietZone = 10
+)
+
+// ErrCode128InvalidText is returned when text cannot be encoded in Code 128.
+var ErrCode128InvalidText = errors.New("invalid Code 128 text")
+
+// code128Patterns holds the bar and space widths of every Code 128 symbol value.
+var code128Patterns = [...]string{
+	"212222", "222122", "222221", "121223", "121322", "131222", "122213", "122312", "132212", "221213",
+	"221312", "231212", "112232", "122132", "122231", "113222", "123122", "123221", "223211", "221132",
+	"221231", "213212", "223112", "312131", "311222", "321122", "321221", "312212", "322112", "322211",
+	"212123", "212321", "232121", "111323", "131123", "131321", "112313", "132113", "132311", "211313",
+	"231113", "231311", "112133", "112331", "132131", "113123", "113321", "133121", "313121", "211331",
+	"231131", "213113", "213311", "213131", "311123", "311321", "331121", "312113", "312311", "332111",
+	"314111", "221411", "431111", "111224", "111422", "121124", "121421", "141122", "141221", "112214",
+	"112412", "122114", "122411", "142112", "142211", "241211", "221114", "413111", "241112", "134111",
+	"111242", "121142", "121241", "114212", "124112", "124211", "411212", "421112", "421211", "212141",
+	"214121", "412121", "111143", "111341", "131141", "114113", "114311", "411113", "411311", "113141",
+	"114131", "311141", "411131", "211412", "211214", "211232", "2331112",
+}
+
+// Code128Modules encodes ASCII text as a Code 128 symbol and returns its modules from
+// left to right, true for a bar. Code sets A, B and C are switched automatically so
+// control characters such as '\r' and long digit runs are supported. Quiet zones are
+// not included.
+//
+// Examples:
+//   - Code128Modules("|099400016550100\r123\r\r0") -> modules, nil
+//   - Code128Modules("ไทย") -> nil, ErrCode128InvalidText
+func Code128Modules(text string) ([]bool, error) {
+	values, err := code128Values(text)
+	if err != nil {
+		return nil, err
+	}
+
+	var modules []bool
+	for _, value := range values {
+		for i, width := range code128Patterns[value] {
+			for range width - '0' {
+				modules = append(modules, i%2 == 0)
+			}
+		}
+	}
+	return modules, nil
+}
+
+// RenderCode128PNG renders text as a Code 128 PNG image with quiet zones.
+// moduleWidth is the width of the narrowest bar in pixels and height the bar height.
+func RenderCode128PNG(text string, moduleWidth, height int) ([]byte, error) {
+	modules, err := code128Layout(text, moduleWidth, height)
+	if err != nil {
+		return nil, err
+	}
+
+	img := image.NewGray(image.Rect(0, 0, len(modules)*moduleWidth, height))
+	for x := range img.Rect.Dx() {
+		c := color.Gray{Y: 0xFF}
+		if modules[x/moduleWidth] {
+			c = color.Gray{}
+		}
+		for y := range height {
+			img.SetGray(x, y, c)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// RenderCode128SVG renders text as a Code 128 SVG document with quiet zones.
+// moduleWidth is the width of the narrowest bar in SVG units and height the bar height.
+func RenderCode128SVG(text string, moduleWidth, height int) (string, error) {
+	modules, err := code128Layout(text, moduleWidth, height)
+	if err != nil {
+		return "", err
+	}
+
+	width := len(modules) * moduleWidth
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`, width, height, width, height)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#fff"/>`, width, height)
+	for start := 0; start < len(modules); {
+		if !modules[start] {
+			start++
+			continue
+		}
+		end := start
+		for end < len(modules) && modules[end] {
+			end++
+		}
+		fmt.Fprintf(&b, `<rect x="%d" width="%d" height="%d"/>`, start*moduleWidth, (end-start)*moduleWidth, height)
+		start = end
+	}
+	b.WriteString("</svg>")
+	return b.String(), nil
+}
+
+// code128Layout validates the render size and returns the modules padded with quiet zones.
+func code128Layout(text string, moduleWidth, height int) ([]bool, error) {
+	if moduleWidth < 1 || height < 1 {
+		return nil, fmt.Errorf("invalid Code 128 size %dx%d", moduleWidth, height)
+	}
+	modules, err := Code128Modules(text)
+	if err != nil {
+		return nil, err
+	}
+
+	padded := make([]bool, code128QuietZone, len(modules)+2*code128QuietZone)
+	padded = append(padded, modules...)
+	return append(padded, make([]bool, code128QuietZone)...), nil
+}
+
+// code128Values converts text to symbol values including start, checksum and stop.
+// Runs of at least four digits use code set C; other characters use code set B,
+// falling back to code set A for control characters.
+func code128Values(text string) ([]int, error) {
+	if text == "" {
+		return nil, fmt.Errorf("%w: empty text", ErrCode128InvalidText)
+	}
+
+	var values []int
+	var set byte // current code set: 'A', 'B' or 'C', zero before the start symbol
+	switchTo := func(next byte) {
+		switch {
+		case set == next:
+			return
+		case set == 0:
+			values = append(values, code128StartA+int(next-'A'))
+		default:
+			values = append(values, code128CodeA-int(next-'A'))
+		}
+		set = next
+	}
+
+	for i := 0; i < len(text); {
+		if digits := code128DigitRun(text[i:]); digits >= 4 {
+			switchTo('C')
+			for end := i + digits&^1; i < end; i += 2 {
+				values = append(values, int(text[i]-'0')*10+int(text[i+1]-'0'))
+			}
+			continue
+		}
+
+		c := text[i]
+		switch {
+		case c > 0x7F:
+			return nil, fmt.Errorf("%w: non-ASCII byte at position %d", ErrCode128InvalidText, i)
+		case c < 0x20:
+			switchTo('A')
+			values = append(values, int(c)+64)
+		case c >= 0x60 || set != 'A':
+			switchTo('B')
+			values = append(values, int(c)-32)
+		default:
+			values = append(values, int(c)-32)
+		}
+		i++
+	}
+
+	checksum := values[0]
+	for i, value := range values[1:] {
+		checksum += (i + 1) * value
+	}
+	return append(values, checksum%103, code128Stop), nil
+}
+
+// code128DigitRun returns the number of leading ASCII digits in s.
+func code128DigitRun(s string) int {
+	n := 0
+	for n < len(s) && s[n] >= '0' && s[n] <= '9' {
+		n++
+	}
+	return n
+}
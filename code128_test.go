@@ -0,0 +1,86 @@
+package xstr
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCode128Values(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []int
+	}{
+		{
+			name:     "code set B",
+			text:     "PJJ123C",
+			expected: []int{104, 48, 42, 42, 17, 18, 19, 35, 55, 106},
+		},
+		{
+			name:     "digit run in code set C",
+			text:     "123456",
+			expected: []int{105, 12, 34, 56, 44, 106},
+		},
+		{
+			name:     "odd digit run falls back to code set B",
+			text:     "12345",
+			expected: []int{105, 12, 34, 100, 21, 54, 106},
+		},
+		{
+			name:     "control character in code set A",
+			text:     "|1\r",
+			expected: []int{104, 92, 17, 101, 77, 17, 106},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := code128Values(tt.text)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, values)
+		})
+	}
+}
+
+func TestCode128Modules(t *testing.T) {
+	modules, err := Code128Modules(billBarcode)
+	require.NoError(t, err)
+
+	// Every symbol is 11 modules wide and the stop symbol 13
+	values, err := code128Values(billBarcode)
+	require.NoError(t, err)
+	assert.Len(t, modules, 11*(len(values)-1)+13)
+	assert.True(t, modules[0])
+	assert.True(t, modules[len(modules)-1])
+
+	for _, text := range []string{"", "ไทย"} {
+		_, err := Code128Modules(text)
+		assert.ErrorIs(t, err, ErrCode128InvalidText)
+	}
+}
+
+func TestRenderCode128(t *testing.T) {
+	modules, err := Code128Modules("PJJ123C")
+	require.NoError(t, err)
+	width := (len(modules) + 2*code128QuietZone) * 3
+
+	data, err := RenderCode128PNG("PJJ123C", 3, 40)
+	require.NoError(t, err)
+	img, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, width, img.Bounds().Dx())
+	assert.Equal(t, 40, img.Bounds().Dy())
+
+	svg, err := RenderCode128SVG("PJJ123C", 3, 40)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="396" height="40"`))
+	assert.True(t, strings.HasSuffix(svg, "</svg>"))
+
+	_, err = RenderCode128SVG("PJJ123C", 0, 40)
+	assert.Error(t, err)
+}
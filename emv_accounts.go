@@ -0,0 +1,101 @@
+package xstr
+
+import (
+	"slices"
+	"strings"
+)
+
+// QRAccount describes one merchant account of a QR: a card network merchant identifier
+// (tags 02-25) or a GUI-based merchant account (tags 26-51).
+type QRAccount struct {
+	Tag           string          `json:"tag"`
+	AID           string          `json:"aid,omitempty"`            // GUI of tags 26-51
+	AIDType       QRPaymentType   `json:"aid_type,omitempty"`       // Mapped AID type of tags 26-51
+	PaymentScheme QRPaymentScheme `json:"payment_scheme,omitempty"` // Mapped payment scheme of tags 26-51
+	CardNetwork   CardNetwork     `json:"card_network,omitempty"`   // Card network of tags 02-25
+	MerchantID    string          `json:"merchant_id"`
+	Reference1    string          `json:"reference_1,omitempty"`
+	Reference2    string          `json:"reference_2,omitempty"`
+	Reference3    string          `json:"reference_3,omitempty"`
+}
+
+// Accounts lists every merchant account of the QR in ascending tag order, so multi-scheme
+// QRs such as SGQR or QRIS expose all the ways they can be paid.
+//
+// Examples:
+//   - DecodeEMVQR("...0216411111111111111129370016A000000677010111...").Accounts() ->
+//     [{Tag: "02", CardNetwork: "Visa", ...}, {Tag: "29", PaymentScheme: "PromptPay", ...}]
+func (e *EMVData) Accounts() []QRAccount {
+	accounts := make([]QRAccount, 0, len(e.CardNetworkAccounts)+len(e.MerchantAccountInfo))
+	for tag, account := range e.CardNetworkAccounts {
+		if account != nil {
+			accounts = append(accounts, QRAccount{Tag: tag, CardNetwork: account.Network, MerchantID: account.Value})
+		}
+	}
+	for _, tag := range sortedAccountTags(e) {
+		account := e.MerchantAccountInfo[tag]
+		accounts = append(accounts, QRAccount{
+			Tag:           tag,
+			AID:           account.AID,
+			AIDType:       account.AIDType,
+			PaymentScheme: account.PaymentScheme,
+			MerchantID:    account.MerchantID,
+			Reference1:    account.Reference1,
+			Reference2:    account.Reference2,
+			Reference3:    account.Reference3,
+		})
+	}
+
+	slices.SortFunc(accounts, func(a, b QRAccount) int {
+		return strings.Compare(a.Tag, b.Tag)
+	})
+	return accounts
+}
+
+// PaymentSchemes reports the known payment schemes the QR can be paid with,
+// without duplicates, in ascending tag order of their first account. Card network
+// accounts (tags 02-25) are reported as their network, e.g. QRPaymentScheme("Visa");
+// tags reserved by EMVCo are skipped since their network is not known.
+//
+// Examples:
+//   - Visa tag 02 and PromptPay tag 29 -> ["Visa", "PromptPay"]
+func (e *EMVData) PaymentSchemes() []QRPaymentScheme {
+	var schemes []QRPaymentScheme
+	for _, account := range e.Accounts() {
+		scheme := account.PaymentScheme
+		if account.CardNetwork != "" && account.CardNetwork != CardNetworkEMVCo {
+			scheme = QRPaymentScheme(account.CardNetwork)
+		}
+		if scheme != "" && scheme != QRSchemeUnknown && !slices.Contains(schemes, scheme) {
+			schemes = append(schemes, scheme)
+		}
+	}
+	return schemes
+}
+
+// QRInfoForScheme returns the QRInfo built from the first merchant account of the scheme
+// in ascending tag order, and false if the QR has no account of the scheme.
+//
+// Examples:
+//   - QRInfoForScheme(QRSchemeQRIS) -> QRInfo{PaymentScheme: "QRIS", ...}, true
+//   - QRInfoForScheme(QRSchemeUPI) -> QRInfo{}, false
+func (e *EMVData) QRInfoForScheme(scheme QRPaymentScheme) (QRInfo, bool) {
+	for _, tag := range sortedAccountTags(e) {
+		if account := e.MerchantAccountInfo[tag]; account.PaymentScheme == scheme {
+			return e.qrInfoFromAccount(account), true
+		}
+	}
+	return QRInfo{}, false
+}
+
+// PreferredQRInfo returns the QRInfo for the first scheme of the preference list that the
+// QR supports, e.g. the schemes a wallet can pay with in its order of preference.
+// It returns false if the QR supports none of them.
+func (e *EMVData) PreferredQRInfo(preferences ...QRPaymentScheme) (QRInfo, bool) {
+	for _, scheme := range preferences {
+		if info, ok := e.QRInfoForScheme(scheme); ok {
+			return info, true
+		}
+	}
+	return QRInfo{}, false
+}
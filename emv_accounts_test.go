@@ -0,0 +1,84 @@
+package xstr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const multiAccountQR = "0002010102110216411111111111111126530014ID.CO.QRIS.WWW01159360001400000010212ID102000000127270014COM.MY.DUITNOW01051234528370014ID.CO.QRIS.WWW011593600014000000251210011COM.EXAMPLE0102X153033605802ID63042B22"
+
+func TestEMVData_Accounts(t *testing.T) {
+	emvData, err := DecodeEMVQR(multiAccountQR)
+	require.NoError(t, err)
+
+	assert.Equal(t, []QRAccount{
+		{Tag: "02", CardNetwork: CardNetworkVisa, MerchantID: "4111111111111111"},
+		{Tag: "26", AID: "ID.CO.QRIS.WWW", AIDType: QRTypeUnknown, PaymentScheme: QRSchemeQRIS, MerchantID: "936000140000001", Reference1: "ID1020000001"},
+		{Tag: "27", AID: "COM.MY.DUITNOW", AIDType: QRTypeUnknown, PaymentScheme: QRSchemeDuitNow, MerchantID: "12345"},
+		{Tag: "28", AID: "ID.CO.QRIS.WWW", AIDType: QRTypeUnknown, PaymentScheme: QRSchemeQRIS, MerchantID: "936000140000002"},
+		{Tag: "51", AID: "COM.EXAMPLE", AIDType: QRTypeUnknown, PaymentScheme: QRSchemeUnknown, MerchantID: "X1"},
+	}, emvData.Accounts())
+	assert.Equal(t, []QRPaymentScheme{"Visa", QRSchemeQRIS, QRSchemeDuitNow}, emvData.PaymentSchemes())
+
+	cardNetworkData, err := DecodeEMVQR(cardNetworkQR)
+	require.NoError(t, err)
+	assert.Equal(t, []QRPaymentScheme{"Visa", "Mastercard", "UnionPay", QRSchemePromptPay}, cardNetworkData.PaymentSchemes())
+
+	empty := &EMVData{}
+	assert.Empty(t, empty.Accounts())
+	assert.Nil(t, empty.PaymentSchemes())
+}
+
+func TestEMVData_QRInfoForScheme(t *testing.T) {
+	emvData, err := DecodeEMVQR(multiAccountQR)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		preferences []QRPaymentScheme
+		merchantID  string
+		scheme      QRPaymentScheme
+		found       bool
+	}{
+		{
+			name:        "first account of the scheme",
+			preferences: []QRPaymentScheme{QRSchemeQRIS},
+			merchantID:  "936000140000001",
+			scheme:      QRSchemeQRIS,
+			found:       true,
+		},
+		{
+			name:        "preference order wins over tag order",
+			preferences: []QRPaymentScheme{QRSchemeUPI, QRSchemeDuitNow, QRSchemeQRIS},
+			merchantID:  "12345",
+			scheme:      QRSchemeDuitNow,
+			found:       true,
+		},
+		{
+			name:        "unsupported schemes",
+			preferences: []QRPaymentScheme{QRSchemePromptPay, QRSchemeUPI},
+		},
+		{
+			name: "no preferences",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, found := emvData.PreferredQRInfo(tt.preferences...)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.merchantID, info.MerchantID)
+			assert.Equal(t, tt.scheme, info.PaymentScheme)
+			if found {
+				assert.Equal(t, "ID", info.CountryCode)
+				assert.Equal(t, POITypeStatic, info.POIMethodType)
+			}
+		})
+	}
+
+	info, found := emvData.QRInfoForScheme(QRSchemeDuitNow)
+	assert.True(t, found)
+	assert.Equal(t, "COM.MY.DUITNOW", info.AID)
+}
@@ -0,0 +1,165 @@
+package xstr
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// EMVMaxAmountLength is the maximum length of the transaction amount (tag 54).
+const EMVMaxAmountLength = 13
+
+// Common EMV amount errors.
+var (
+	ErrEMVInvalidAmount   = errors.New("invalid EMV amount")
+	ErrEMVUnknownCurrency = errors.New("unknown EMV currency")
+)
+
+// checkEMVAmount validates an amount in EMV string form for a currency with the given minor units.
+// The amount must be positive, use '.' as the decimal separator and have no more decimals
+// than the currency allows.
+func checkEMVAmount(amount string, minorUnits int) error {
+	if amount == "" || len(amount) > EMVMaxAmountLength {
+		return fmt.Errorf("%w: %q must be 1 to %d characters", ErrEMVInvalidAmount, amount, EMVMaxAmountLength)
+	}
+
+	dot := -1
+	nonZero := false
+	for i := 0; i < len(amount); i++ {
+		c := amount[i]
+		switch {
+		case c == '.' && dot == -1:
+			dot = i
+		case c >= '0' && c <= '9':
+			nonZero = nonZero || c != '0'
+		default:
+			return fmt.Errorf("%w: %q contains %q", ErrEMVInvalidAmount, amount, c)
+		}
+	}
+
+	if dot != -1 {
+		decimals := len(amount) - dot - 1
+		switch {
+		case dot == 0 || decimals == 0:
+			return fmt.Errorf("%w: %q has a misplaced decimal separator", ErrEMVInvalidAmount, amount)
+		case decimals > minorUnits:
+			return fmt.Errorf("%w: %q has more than %d decimal places", ErrEMVInvalidAmount, amount, minorUnits)
+		}
+	}
+	if !nonZero {
+		return fmt.Errorf("%w: %q must be greater than zero", ErrEMVInvalidAmount, amount)
+	}
+	return nil
+}
+
+// parseEMVAmountMinorUnits validates an EMV amount and converts it to minor units,
+// e.g. "125.5" with 2 minor units -> 12550.
+func parseEMVAmountMinorUnits(amount string, minorUnits int) (int64, error) {
+	if err := checkEMVAmount(amount, minorUnits); err != nil {
+		return 0, err
+	}
+
+	whole, fraction, _ := strings.Cut(amount, ".")
+	digits := whole + fraction + strings.Repeat("0", minorUnits-len(fraction))
+	value, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q: %v", ErrEMVInvalidAmount, amount, err)
+	}
+	return value, nil
+}
+
+// ParseEMVAmount parses an EMV amount (tag 54 or 56) to integer minor units of the numeric
+// ISO 4217 currency (tag 53). The amount must be 1 to 13 characters of digits with an optional
+// '.' decimal separator, greater than zero and within the currency exponent. Leading zeros
+// are accepted; use FormatEMVAmount for the canonical form.
+//
+// Examples:
+//   - ParseEMVAmount("125.5", "764") -> 12550
+//   - ParseEMVAmount("1000", "392") -> 1000
+//   - ParseEMVAmount("1,000.00", "764") -> ErrEMVInvalidAmount
+func ParseEMVAmount(amount, currency string) (int64, error) {
+	details, ok := lookupCurrency(currency)
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrEMVUnknownCurrency, currency)
+	}
+	return parseEMVAmountMinorUnits(amount, details.MinorUnits)
+}
+
+// FormatEMVAmount formats an amount in minor units of the numeric ISO 4217 currency
+// in canonical EMV form: no leading zeros and exactly as many decimals as the currency exponent.
+//
+// Examples:
+//   - FormatEMVAmount(12550, "764") -> "125.50"
+//   - FormatEMVAmount(1000, "392") -> "1000"
+func FormatEMVAmount(minor int64, currency string) (string, error) {
+	details, ok := lookupCurrency(currency)
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrEMVUnknownCurrency, currency)
+	}
+	if minor <= 0 {
+		return "", fmt.Errorf("%w: %d must be greater than zero", ErrEMVInvalidAmount, minor)
+	}
+	amount := formatEMVAmountMinorUnits(minor, details.MinorUnits)
+	if len(amount) > EMVMaxAmountLength {
+		return "", fmt.Errorf("%w: %q must be 1 to %d characters", ErrEMVInvalidAmount, amount, EMVMaxAmountLength)
+	}
+	return amount, nil
+}
+
+// AmountMinorUnits returns the transaction amount (tag 54) in minor units of the
+// transaction currency, or 0 when the QR has no amount.
+func (e *EMVData) AmountMinorUnits() (int64, error) {
+	if e.TransactionAmount == "" {
+		return 0, nil
+	}
+	return ParseEMVAmount(e.TransactionAmount, e.TransactionCurrency)
+}
+
+// ConvenienceFeeMinorUnits returns the fixed convenience fee (tag 56) in minor units of the
+// transaction currency, or 0 when the QR has no fixed fee.
+func (e *EMVData) ConvenienceFeeMinorUnits() (int64, error) {
+	if e.ValueOfConvenienceFee == "" {
+		return 0, nil
+	}
+	return ParseEMVAmount(e.ValueOfConvenienceFee, e.TransactionCurrency)
+}
+
+// checkAmounts validates the amount fields (tags 54 and 56) and returns the tag of the first
+// invalid one. The currency exponent is enforced when the currency is known; otherwise only
+// the syntax is checked, since the exponent cannot be determined.
+func (e *EMVData) checkAmounts() (string, error) {
+	minorUnits := EMVMaxAmountLength
+	if currency, ok := lookupCurrency(e.TransactionCurrency); ok {
+		minorUnits = currency.MinorUnits
+	}
+	amounts := []struct {
+		tag   string
+		value string
+	}{
+		{"54", e.TransactionAmount},
+		{"56", e.ValueOfConvenienceFee},
+	}
+	for _, amount := range amounts {
+		if amount.value == "" {
+			continue
+		}
+		if err := checkEMVAmount(amount.value, minorUnits); err != nil {
+			return amount.tag, err
+		}
+	}
+	return "", nil
+}
+
+// formatEMVAmountMinorUnits formats an amount in minor units in canonical EMV form: no leading
+// zeros and exactly minorUnits decimals, e.g. 12550 with 2 minor units -> "125.50".
+func formatEMVAmountMinorUnits(minor int64, minorUnits int) string {
+	digits := strconv.FormatInt(minor, 10)
+	if minorUnits == 0 {
+		return digits
+	}
+	if len(digits) <= minorUnits {
+		digits = strings.Repeat("0", minorUnits-len(digits)+1) + digits
+	}
+	return digits[:len(digits)-minorUnits] + "." + digits[len(digits)-minorUnits:]
+}
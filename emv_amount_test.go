@@ -0,0 +1,166 @@
+package xstr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEMVAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   string
+		currency string
+		expected int64
+		wantErr  error
+	}{
+		{name: "two decimals", amount: "125.50", currency: "764", expected: 12550},
+		{name: "one decimal", amount: "125.5", currency: "764", expected: 12550},
+		{name: "whole amount", amount: "10", currency: "764", expected: 1000},
+		{name: "leading zeros", amount: "010.00", currency: "764", expected: 1000},
+		{name: "zero exponent", amount: "1000", currency: "392", expected: 1000},
+		{name: "13 characters", amount: "9999999999.99", currency: "764", expected: 999999999999},
+		{name: "thousands separator", amount: "1,000.00", currency: "764", wantErr: ErrEMVInvalidAmount},
+		{name: "negative", amount: "-5", currency: "764", wantErr: ErrEMVInvalidAmount},
+		{name: "exponent notation", amount: "1e3", currency: "764", wantErr: ErrEMVInvalidAmount},
+		{name: "14 characters", amount: "99999999999.99", currency: "764", wantErr: ErrEMVInvalidAmount},
+		{name: "too many decimals", amount: "1.234", currency: "764", wantErr: ErrEMVInvalidAmount},
+		{name: "decimals in zero exponent currency", amount: "100.5", currency: "392", wantErr: ErrEMVInvalidAmount},
+		{name: "comma decimal separator", amount: "10,50", currency: "978", wantErr: ErrEMVInvalidAmount},
+		{name: "trailing separator", amount: "10.", currency: "764", wantErr: ErrEMVInvalidAmount},
+		{name: "leading separator", amount: ".5", currency: "764", wantErr: ErrEMVInvalidAmount},
+		{name: "whitespace", amount: " 10", currency: "764", wantErr: ErrEMVInvalidAmount},
+		{name: "zero", amount: "0.00", currency: "764", wantErr: ErrEMVInvalidAmount},
+		{name: "empty", amount: "", currency: "764", wantErr: ErrEMVInvalidAmount},
+		{name: "unknown currency", amount: "10.00", currency: "999", wantErr: ErrEMVUnknownCurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseEMVAmount(tt.amount, tt.currency)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestFormatEMVAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		minor    int64
+		currency string
+		expected string
+		wantErr  error
+	}{
+		{name: "two decimals", minor: 12550, currency: "764", expected: "125.50"},
+		{name: "below one unit", minor: 5, currency: "764", expected: "0.05"},
+		{name: "whole amount", minor: 100, currency: "840", expected: "1.00"},
+		{name: "zero exponent", minor: 1000, currency: "392", expected: "1000"},
+		{name: "13 characters", minor: 999999999999, currency: "764", expected: "9999999999.99"},
+		{name: "too long", minor: 1000000000000, currency: "764", wantErr: ErrEMVInvalidAmount},
+		{name: "zero", minor: 0, currency: "764", wantErr: ErrEMVInvalidAmount},
+		{name: "negative", minor: -5, currency: "764", wantErr: ErrEMVInvalidAmount},
+		{name: "unknown currency", minor: 100, currency: "999", wantErr: ErrEMVUnknownCurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := FormatEMVAmount(tt.minor, tt.currency)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+
+			minor, err := ParseEMVAmount(result, tt.currency)
+			require.NoError(t, err)
+			assert.Equal(t, tt.minor, minor)
+		})
+	}
+}
+
+func TestDecodeEMVQR_AmountValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		qrString string
+		wantErr  bool
+	}{
+		{name: "thousands separator", qrString: "00020101021229370016A00000067701011101130066812345678530376454081,000.005802TH63044BA7", wantErr: true},
+		{name: "negative", qrString: "00020101021229370016A0000006770101110113006681234567853037645402-55802TH6304309D", wantErr: true},
+		{name: "exponent notation", qrString: "00020101021229370016A00000067701011101130066812345678530376454031e35802TH63047387", wantErr: true},
+		{name: "14 characters", qrString: "00020101021229370016A000000677010111011300668123456785303764541499999999999.995802TH6304E010", wantErr: true},
+		{name: "too many decimals for THB", qrString: "00020101021229370016A00000067701011101130066812345678530376454051.2345802TH6304A045", wantErr: true},
+		{name: "decimals for JPY", qrString: "00020101021229370016A0000006770101110113006681234567853033925405100.55802TH63042A75", wantErr: true},
+		{name: "invalid convenience fee", qrString: "00020101021229370016A000000677010111011300668123456785303764540510.005502025603abc5802TH6304BAD4", wantErr: true},
+		{name: "unknown currency checks syntax only", qrString: "00020101021229370016A00000067701011101130066812345678530399954051.2345802TH63041A27"},
+		{name: "leading zeros", qrString: "00020101021229370016A0000006770101110113006681234567853037645405010.5550202560155802TH63049BCD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			emvData, err := DecodeEMVQR(tt.qrString)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrEMVInvalidField)
+				assert.ErrorIs(t, err, ErrEMVInvalidAmount)
+				assert.Nil(t, emvData)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, emvData)
+		})
+	}
+}
+
+func TestEMVData_AmountMinorUnits(t *testing.T) {
+	emvData, err := DecodeEMVQR("00020101021229370016A0000006770101110113006681234567853037645405010.5550202560155802TH63049BCD")
+	require.NoError(t, err)
+
+	amount, err := emvData.AmountMinorUnits()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1050), amount)
+
+	fee, err := emvData.ConvenienceFeeMinorUnits()
+	require.NoError(t, err)
+	assert.Equal(t, int64(500), fee)
+
+	emvData.TransactionAmount, emvData.ValueOfConvenienceFee = "", ""
+	amount, err = emvData.AmountMinorUnits()
+	require.NoError(t, err)
+	assert.Zero(t, amount)
+	fee, err = emvData.ConvenienceFeeMinorUnits()
+	require.NoError(t, err)
+	assert.Zero(t, fee)
+}
+
+func TestEMVData_SetAmountMinorUnits(t *testing.T) {
+	emvData, err := DecodeEMVQR(staticPromptPayQR)
+	require.NoError(t, err)
+
+	require.NoError(t, emvData.SetAmountMinorUnits(10050, WithBillNumber("INV01")))
+	assert.Equal(t, "100.50", emvData.TransactionAmount)
+	assert.Equal(t, POITypeDynamic, emvData.POIMethodType)
+	assert.Equal(t, "INV01", emvData.AdditionalData["01"])
+
+	assert.ErrorIs(t, emvData.SetAmountMinorUnits(0), ErrEMVInvalidAmount)
+	assert.Equal(t, "100.50", emvData.TransactionAmount)
+}
+
+func TestEMVData_Encode_InvalidAmount(t *testing.T) {
+	emvData, err := DecodeEMVQR(staticPromptPayQR)
+	require.NoError(t, err)
+
+	emvData.TransactionAmount = "1,000.00"
+	_, err = emvData.Encode()
+	assert.ErrorIs(t, err, ErrEMVInvalidAmount)
+
+	emvData.TransactionAmount = "1000.00"
+	emvData.ValueOfConvenienceFee = "-5"
+	_, err = emvData.Encode()
+	assert.ErrorIs(t, err, ErrEMVInvalidAmount)
+}
@@ -0,0 +1,378 @@
+package xstr
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// ErrBatchColumnNotFound is reported for CSV records without the configured payload column.
+var ErrBatchColumnNotFound = errors.New("csv payload column not found")
+
+// EMVFailureKind classifies why a payload failed to decode.
+type EMVFailureKind string
+
+// EMV failure kind constants used by batch statistics
+const (
+	EMVFailureTooShort         EMVFailureKind = "too_short"
+	EMVFailurePayloadTooLong   EMVFailureKind = "payload_too_long"
+	EMVFailureInvalidLength    EMVFailureKind = "invalid_length"
+	EMVFailureInvalidData      EMVFailureKind = "invalid_data_length"
+	EMVFailureInvalidField     EMVFailureKind = "invalid_field"
+	EMVFailureInvalidTag       EMVFailureKind = "invalid_tag"
+	EMVFailureDuplicateTag     EMVFailureKind = "duplicate_tag"
+	EMVFailureTrailingData     EMVFailureKind = "trailing_data"
+	EMVFailureFormatIndicator  EMVFailureKind = "format_indicator"
+	EMVFailureCRCNotFound      EMVFailureKind = "crc_not_found"
+	EMVFailureCRCMismatch      EMVFailureKind = "crc_mismatch"
+	EMVFailureSchemeNotAllowed EMVFailureKind = "scheme_not_allowed"
+	EMVFailureInvalidTimestamp EMVFailureKind = "invalid_timestamp"
+	EMVFailureExpired          EMVFailureKind = "expired"
+	EMVFailureNotYetValid      EMVFailureKind = "not_yet_valid"
+	EMVFailureMalformedRecord  EMVFailureKind = "malformed_record"
+	EMVFailureUnknown          EMVFailureKind = "unknown"
+)
+
+// BatchResult is the decode result of a single input line.
+type BatchResult struct {
+	Line    int      // 1-based line number in the input
+	Payload string   // Raw payload as read from the input
+	Data    *EMVData // Decoded data, nil on failure
+	Err     error    // Decode error, nil on success
+}
+
+// BatchStats summarizes a batch decode run.
+type BatchStats struct {
+	Total     int                     `json:"total"`
+	Succeeded int                     `json:"succeeded"`
+	Failed    int                     `json:"failed"`
+	ByScheme  map[QRPaymentScheme]int `json:"by_scheme"`  // Successful decodes by primary scheme
+	ByFailure map[EMVFailureKind]int  `json:"by_failure"` // Failed decodes by failure kind
+}
+
+// BatchDecoder decodes newline- or CSV-delimited EMV payloads from an io.Reader
+// with bounded worker concurrency. A BatchDecoder is safe for concurrent use.
+type BatchDecoder struct {
+	decoder   *Decoder
+	workers   int
+	csv       bool
+	csvColumn int
+	csvHeader bool
+}
+
+// BatchOption configures a BatchDecoder.
+type BatchOption func(*BatchDecoder)
+
+// batchMaxLineLength bounds the bytes kept of a single input line.
+const batchMaxLineLength = 1024 * 1024
+
+// defaultBatchWorkers is the number of decode workers when none is configured.
+const defaultBatchWorkers = 4
+
+// NewBatchDecoder creates a BatchDecoder configured with the given options.
+// Without options it reads one payload per line and decodes it like DecodeEMVQR.
+func NewBatchDecoder(opts ...BatchOption) *BatchDecoder {
+	b := &BatchDecoder{
+		decoder: defaultDecoder,
+		workers: defaultBatchWorkers,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// WithBatchDecoder sets the Decoder used for each payload.
+func WithBatchDecoder(d *Decoder) BatchOption {
+	return func(b *BatchDecoder) {
+		if d != nil {
+			b.decoder = d
+		}
+	}
+}
+
+// WithBatchWorkers sets the maximum number of payloads decoded concurrently.
+func WithBatchWorkers(n int) BatchOption {
+	return func(b *BatchDecoder) {
+		if n > 0 {
+			b.workers = n
+		}
+	}
+}
+
+// WithBatchCSV reads CSV records and takes the payload from the given 0-based column.
+// If header is true the first record is skipped.
+func WithBatchCSV(column int, header bool) BatchOption {
+	return func(b *BatchDecoder) {
+		b.csv = true
+		b.csvColumn = column
+		b.csvHeader = header
+	}
+}
+
+// batchJob is a payload waiting to be decoded, with a slot for its ordered result.
+type batchJob struct {
+	result BatchResult
+	done   chan BatchResult
+}
+
+// Decode reads payloads from r, decodes them concurrently and calls fn with each
+// result in input order. Empty lines are skipped. Decoding stops when ctx is
+// cancelled, when fn returns an error, or when r fails; that error is returned
+// together with the statistics collected so far.
+func (b *BatchDecoder) Decode(ctx context.Context, r io.Reader, fn func(BatchResult) error) (*BatchStats, error) {
+	stats := &BatchStats{
+		ByScheme:  make(map[QRPaymentScheme]int),
+		ByFailure: make(map[EMVFailureKind]int),
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan *batchJob)
+	ordered := make(chan *batchJob, b.workers)
+
+	// Workers decode payloads; each result has its own buffered slot so workers never block
+	var wg sync.WaitGroup
+	for i := 0; i < b.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				if job.result.Err == nil {
+					job.result.Data, job.result.Err = b.decoder.Decode(job.result.Payload)
+				}
+				job.done <- job.result
+			}
+		}()
+	}
+
+	// Reader feeds jobs to workers and, in input order, to the consumer
+	var readErr error
+	go func() {
+		defer close(ordered)
+		defer close(jobs)
+		readErr = b.read(r, func(result BatchResult) bool {
+			job := &batchJob{result: result, done: make(chan BatchResult, 1)}
+			select {
+			case ordered <- job:
+			case <-ctx.Done():
+				return false
+			}
+			select {
+			case jobs <- job:
+			case <-ctx.Done():
+				return false
+			}
+			return true
+		})
+	}()
+
+	var err error
+	for job := range ordered {
+		var result BatchResult
+		select {
+		case result = <-job.done:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			err = ctx.Err()
+			break
+		}
+
+		stats.add(result)
+		if err = fn(result); err != nil {
+			break
+		}
+	}
+
+	cancel()
+	for range ordered {
+		// Drain so the reader can exit
+	}
+	wg.Wait()
+
+	if err == nil {
+		err = readErr
+	}
+	return stats, err
+}
+
+// read splits r into payloads and calls emit for each; emit returns false to stop.
+func (b *BatchDecoder) read(r io.Reader, emit func(BatchResult) bool) error {
+	if b.csv {
+		return b.readCSV(r, emit)
+	}
+
+	reader := bufio.NewReaderSize(r, 64*1024)
+	line := 0
+	for {
+		text, tooLong, err := readBatchLine(reader)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		line++
+
+		// Lines longer than the limit are reported on their own and reading continues
+		if tooLong {
+			result := BatchResult{Line: line, Err: fmt.Errorf("%w: line exceeds %d bytes", ErrEMVPayloadTooLong, batchMaxLineLength)}
+			if !emit(result) {
+				return nil
+			}
+			continue
+		}
+
+		payload := strings.TrimRight(text, "\r")
+		if strings.TrimSpace(payload) == "" {
+			continue
+		}
+		if !emit(BatchResult{Line: line, Payload: payload}) {
+			return nil
+		}
+	}
+}
+
+// readBatchLine reads the next line without its line ending. The rest of a line longer
+// than batchMaxLineLength is discarded and tooLong is set. It returns io.EOF only when
+// no line is left.
+func readBatchLine(reader *bufio.Reader) (text string, tooLong bool, err error) {
+	var buf []byte
+	read := false
+	for {
+		chunk, isPrefix, err := reader.ReadLine()
+		if err != nil {
+			if err == io.EOF && read {
+				return string(buf), tooLong, nil
+			}
+			return "", false, err
+		}
+		read = true
+		if !tooLong {
+			if len(buf)+len(chunk) > batchMaxLineLength {
+				tooLong, buf = true, nil
+			} else {
+				buf = append(buf, chunk...)
+			}
+		}
+		if !isPrefix {
+			return string(buf), tooLong, nil
+		}
+	}
+}
+
+// readCSV reads CSV records and emits the configured column of each.
+func (b *BatchDecoder) readCSV(r io.Reader, emit func(BatchResult) bool) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	first := true
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			// Malformed records are reported per line and reading continues
+			result := BatchResult{Line: parseErr.StartLine, Err: err}
+			first = false
+			if !emit(result) {
+				return nil
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		line, _ := reader.FieldPos(0)
+		if first {
+			first = false
+			if b.csvHeader {
+				continue
+			}
+		}
+
+		result := BatchResult{Line: line}
+		if b.csvColumn >= 0 && b.csvColumn < len(record) {
+			result.Payload = strings.TrimSpace(record[b.csvColumn])
+			if result.Payload == "" {
+				continue
+			}
+		} else {
+			result.Err = fmt.Errorf("%w: %d", ErrBatchColumnNotFound, b.csvColumn)
+		}
+		if !emit(result) {
+			return nil
+		}
+	}
+}
+
+// add records a single result in the statistics.
+func (s *BatchStats) add(result BatchResult) {
+	s.Total++
+	if result.Err != nil {
+		s.Failed++
+		s.ByFailure[ClassifyEMVError(result.Err)]++
+		return
+	}
+	s.Succeeded++
+	scheme := result.Data.QRInfo().PaymentScheme
+	if scheme == "" {
+		scheme = QRSchemeUnknown
+	}
+	s.ByScheme[scheme]++
+}
+
+// ClassifyEMVError maps a decode error to its failure kind.
+func ClassifyEMVError(err error) EMVFailureKind {
+	var parseErr *csv.ParseError
+	switch {
+	case err == nil:
+		return ""
+	case errors.As(err, &parseErr), errors.Is(err, ErrBatchColumnNotFound):
+		return EMVFailureMalformedRecord
+	case errors.Is(err, ErrEMVTooShort):
+		return EMVFailureTooShort
+	case errors.Is(err, ErrEMVPayloadTooLong):
+		return EMVFailurePayloadTooLong
+	case errors.Is(err, ErrEMVInvalidLength):
+		return EMVFailureInvalidLength
+	case errors.Is(err, ErrEMVInvalidData):
+		return EMVFailureInvalidData
+	case errors.Is(err, ErrEMVInvalidField):
+		return EMVFailureInvalidField
+	case errors.Is(err, ErrEMVInvalidTag):
+		return EMVFailureInvalidTag
+	case errors.Is(err, ErrEMVDuplicateTag):
+		return EMVFailureDuplicateTag
+	case errors.Is(err, ErrEMVTrailingData):
+		return EMVFailureTrailingData
+	case errors.Is(err, ErrEMVFormatIndicator):
+		return EMVFailureFormatIndicator
+	case errors.Is(err, ErrEMVCRCNotFound):
+		return EMVFailureCRCNotFound
+	case errors.Is(err, ErrEMVCRCMismatch):
+		return EMVFailureCRCMismatch
+	case errors.Is(err, ErrEMVSchemeNotAllowed):
+		return EMVFailureSchemeNotAllowed
+	case errors.Is(err, ErrEMVInvalidTimestamp):
+		return EMVFailureInvalidTimestamp
+	case errors.Is(err, ErrEMVQRExpired):
+		return EMVFailureExpired
+	case errors.Is(err, ErrEMVQRNotYetValid):
+		return EMVFailureNotYetValid
+	default:
+		return EMVFailureUnknown
+	}
+}
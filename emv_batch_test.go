@@ -0,0 +1,198 @@
+package xstr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	batchPromptPayQR = "00020101021129370016A000000677010111011300668123456785802TH5303764540510.0063044ABE"
+	batchBillQR      = "00020101021230590016A000000677010112011501075370008820502061234560306ABCDEF530376454031005802TH62210105INV010708TERM00016304C43B"
+)
+
+func TestBatchDecoder_Lines(t *testing.T) {
+	input := strings.Join([]string{
+		batchPromptPayQR,
+		"",
+		batchBillQR + "\r",
+		"010201630441C6",
+		"00",
+		"0002010102115802TH",
+	}, "\n")
+
+	var results []BatchResult
+	stats, err := NewBatchDecoder(WithBatchWorkers(3)).Decode(context.Background(), strings.NewReader(input), func(result BatchResult) error {
+		results = append(results, result)
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.Len(t, results, 5)
+	assert.Equal(t, []int{1, 3, 4, 5, 6}, []int{results[0].Line, results[1].Line, results[2].Line, results[3].Line, results[4].Line})
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, "0066812345678", results[0].Data.MerchantAccountInfo["29"].MerchantID)
+	assert.NoError(t, results[1].Err)
+	assert.ErrorIs(t, results[2].Err, ErrEMVCRCMismatch)
+	assert.ErrorIs(t, results[3].Err, ErrEMVTooShort)
+	assert.ErrorIs(t, results[4].Err, ErrEMVCRCNotFound)
+
+	assert.Equal(t, 5, stats.Total)
+	assert.Equal(t, 2, stats.Succeeded)
+	assert.Equal(t, 3, stats.Failed)
+	assert.Equal(t, map[QRPaymentScheme]int{QRSchemePromptPay: 2}, stats.ByScheme)
+	assert.Equal(t, map[EMVFailureKind]int{
+		EMVFailureCRCMismatch: 1,
+		EMVFailureTooShort:    1,
+		EMVFailureCRCNotFound: 1,
+	}, stats.ByFailure)
+}
+
+func TestBatchDecoder_ExpiryStats(t *testing.T) {
+	clock := func() time.Time { return time.Date(2027, 6, 1, 0, 0, 0, 0, time.UTC) }
+	input := strings.Join([]string{expiryPayNowQR, expiryPayNowInvalidQR, batchPromptPayQR}, "\n")
+
+	batch := NewBatchDecoder(WithBatchDecoder(NewDecoder(WithExpiryCheck(clock))))
+	stats, err := batch.Decode(context.Background(), strings.NewReader(input), func(BatchResult) error { return nil })
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, stats.Succeeded)
+	assert.Equal(t, map[EMVFailureKind]int{
+		EMVFailureExpired:          1,
+		EMVFailureInvalidTimestamp: 1,
+	}, stats.ByFailure)
+}
+
+func TestBatchDecoder_LongLine(t *testing.T) {
+	input := strings.Join([]string{
+		strings.Repeat("0", 2*1024*1024),
+		batchPromptPayQR,
+		strings.Repeat("0", batchMaxLineLength+1),
+	}, "\n")
+
+	var results []BatchResult
+	stats, err := NewBatchDecoder().Decode(context.Background(), strings.NewReader(input), func(result BatchResult) error {
+		results = append(results, result)
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.Len(t, results, 3)
+	assert.Equal(t, 1, results[0].Line)
+	assert.Empty(t, results[0].Payload)
+	assert.ErrorIs(t, results[0].Err, ErrEMVPayloadTooLong)
+	assert.Equal(t, 2, results[1].Line)
+	assert.NoError(t, results[1].Err)
+	assert.Equal(t, 3, results[2].Line)
+	assert.ErrorIs(t, results[2].Err, ErrEMVPayloadTooLong)
+
+	assert.Equal(t, 1, stats.Succeeded)
+	assert.Equal(t, map[EMVFailureKind]int{EMVFailurePayloadTooLong: 2}, stats.ByFailure)
+}
+
+func TestBatchDecoder_PreservesOrder(t *testing.T) {
+	var b strings.Builder
+	for i := 0; i < 500; i++ {
+		if i%7 == 0 {
+			b.WriteString("bad\n")
+		} else {
+			b.WriteString(batchPromptPayQR + "\n")
+		}
+	}
+
+	line := 0
+	stats, err := NewBatchDecoder(WithBatchWorkers(8)).Decode(context.Background(), strings.NewReader(b.String()), func(result BatchResult) error {
+		line++
+		assert.Equal(t, line, result.Line)
+		assert.Equal(t, (line-1)%7 == 0, result.Err != nil)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 500, stats.Total)
+	assert.Equal(t, 72, stats.Failed)
+}
+
+func TestBatchDecoder_CSV(t *testing.T) {
+	input := "id,payload\n" +
+		"1," + batchPromptPayQR + "\n" +
+		"2,\"" + batchBillQR + "\"\n" +
+		"3\n" +
+		"4,\n"
+
+	var results []BatchResult
+	stats, err := NewBatchDecoder(WithBatchCSV(1, true)).Decode(context.Background(), strings.NewReader(input), func(result BatchResult) error {
+		results = append(results, result)
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.Len(t, results, 3)
+	assert.Equal(t, 2, results[0].Line)
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, 3, results[1].Line)
+	assert.NoError(t, results[1].Err)
+	assert.Equal(t, 4, results[2].Line)
+	assert.ErrorIs(t, results[2].Err, ErrBatchColumnNotFound)
+	assert.Equal(t, 1, stats.ByFailure[EMVFailureMalformedRecord])
+}
+
+func TestBatchDecoder_CallbackError(t *testing.T) {
+	input := strings.Repeat(batchPromptPayQR+"\n", 100)
+	stop := errors.New("stop")
+
+	calls := 0
+	stats, err := NewBatchDecoder().Decode(context.Background(), strings.NewReader(input), func(result BatchResult) error {
+		calls++
+		if calls == 10 {
+			return stop
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 10, calls)
+	assert.Equal(t, 10, stats.Total)
+}
+
+func TestBatchDecoder_ContextCancel(t *testing.T) {
+	input := strings.Repeat(batchPromptPayQR+"\n", 1000)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	calls := 0
+	_, err := NewBatchDecoder().Decode(ctx, strings.NewReader(input), func(result BatchResult) error {
+		calls++
+		if calls == 5 {
+			cancel()
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, calls, 1000)
+}
+
+func TestClassifyEMVError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want EMVFailureKind
+	}{
+		{nil, ""},
+		{ErrEMVTooShort, EMVFailureTooShort},
+		{fmt.Errorf("%w at tag 01", ErrEMVInvalidData), EMVFailureInvalidData},
+		{fmt.Errorf("%w: expected 0000, got FFFF", ErrEMVCRCMismatch), EMVFailureCRCMismatch},
+		{ErrEMVSchemeNotAllowed, EMVFailureSchemeNotAllowed},
+		{fmt.Errorf("%w: PayNow expiry in tag 26", ErrEMVInvalidTimestamp), EMVFailureInvalidTimestamp},
+		{fmt.Errorf("%w at 2027-01-01", ErrEMVQRExpired), EMVFailureExpired},
+		{ErrEMVQRNotYetValid, EMVFailureNotYetValid},
+		{errors.New("boom"), EMVFailureUnknown},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, ClassifyEMVError(tt.err))
+	}
+}
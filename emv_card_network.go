@@ -0,0 +1,117 @@
+package xstr
+
+import (
+	"errors"
+	"fmt"
+)
+
+// CardNetwork represents the card network that owns a primitive merchant account tag (02-25).
+type CardNetwork string
+
+// Card network constants for the EMVCo tag assignments of tags 02-25.
+const (
+	CardNetworkVisa       CardNetwork = "Visa"       // Tags 02-03
+	CardNetworkMastercard CardNetwork = "Mastercard" // Tags 04-05
+	CardNetworkEMVCo      CardNetwork = "EMVCo"      // Tags 06-08 and 17-25, reserved by EMVCo
+	CardNetworkDiscover   CardNetwork = "Discover"   // Tags 09-10
+	CardNetworkAmex       CardNetwork = "Amex"       // Tags 11-12
+	CardNetworkJCB        CardNetwork = "JCB"        // Tags 13-14
+	CardNetworkUnionPay   CardNetwork = "UnionPay"   // Tags 15-16
+	CardNetworkUnknown    CardNetwork = "Unknown"
+)
+
+// Card network merchant identifier lengths for PAN-like networks.
+const (
+	cardMerchantPANMinLength = 8
+	cardMerchantPANMaxLength = 19
+)
+
+// ErrEMVInvalidCardAccount is returned when a card network merchant identifier is malformed.
+var ErrEMVInvalidCardAccount = errors.New("invalid card network merchant account")
+
+// CardNetworkAccount represents a primitive card network merchant identifier (tags 02-25).
+// Unlike the GUI-based accounts in tags 26-51 the value is not a template.
+type CardNetworkAccount struct {
+	Network CardNetwork `json:"network"` // Card network owning the tag
+	Value   string      `json:"value"`   // Merchant identifier, a merchant PAN for Visa, Mastercard and Discover
+}
+
+// Validate checks the merchant identifier. Visa, Mastercard and Discover identifiers are
+// merchant PANs and must be 8 to 19 digits passing the Luhn check; other networks only
+// require a non-empty value.
+//
+// Examples:
+//   - (&CardNetworkAccount{Network: CardNetworkVisa, Value: "4111111111111111"}).Validate() -> nil
+//   - (&CardNetworkAccount{Network: CardNetworkVisa, Value: "4111111111111112"}).Validate() -> ErrEMVInvalidCardAccount
+func (a *CardNetworkAccount) Validate() error {
+	if a.Value == "" {
+		return fmt.Errorf("%w: empty %s merchant identifier", ErrEMVInvalidCardAccount, a.Network)
+	}
+
+	switch a.Network {
+	case CardNetworkVisa, CardNetworkMastercard, CardNetworkDiscover:
+		if len(a.Value) < cardMerchantPANMinLength || len(a.Value) > cardMerchantPANMaxLength || !isDigits(a.Value) {
+			return fmt.Errorf("%w: %s merchant PAN must be %d to %d digits", ErrEMVInvalidCardAccount, a.Network, cardMerchantPANMinLength, cardMerchantPANMaxLength)
+		}
+		if !luhnValid(a.Value) {
+			return fmt.Errorf("%w: %s merchant PAN fails the Luhn check", ErrEMVInvalidCardAccount, a.Network)
+		}
+	}
+	return nil
+}
+
+// ValidateCardNetworkAccounts validates every card network account in ascending tag order
+// and returns the first error, prefixed with its tag.
+func (e *EMVData) ValidateCardNetworkAccounts() error {
+	for n := 2; n <= 25; n++ {
+		tag := fmt.Sprintf("%02d", n)
+		account := e.CardNetworkAccounts[tag]
+		if account == nil {
+			continue
+		}
+		if err := account.Validate(); err != nil {
+			return fmt.Errorf("tag %s: %w", tag, err)
+		}
+	}
+	return nil
+}
+
+// mapCardNetwork returns the card network assigned to a tag in 02-25.
+func mapCardNetwork(tag string) CardNetwork {
+	switch tag {
+	case "02", "03":
+		return CardNetworkVisa
+	case "04", "05":
+		return CardNetworkMastercard
+	case "09", "10":
+		return CardNetworkDiscover
+	case "11", "12":
+		return CardNetworkAmex
+	case "13", "14":
+		return CardNetworkJCB
+	case "15", "16":
+		return CardNetworkUnionPay
+	}
+	if tag >= "06" && tag <= "25" {
+		return CardNetworkEMVCo
+	}
+	return CardNetworkUnknown
+}
+
+// luhnValid reports whether a digit string passes the Luhn (mod 10) check.
+func luhnValid(digits string) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
@@ -0,0 +1,104 @@
+package xstr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const cardNetworkQR = "00020101021102164111111111111111041651051051051051001516622000001234567829370016A000000677010111011300668123456785204581253037645802TH5904SHOP6007BANGKOK63048424"
+
+func TestDecodeEMVQR_CardNetworkAccounts(t *testing.T) {
+	emvData, err := DecodeEMVQR(cardNetworkQR)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]*CardNetworkAccount{
+		"02": {Network: CardNetworkVisa, Value: "4111111111111111"},
+		"04": {Network: CardNetworkMastercard, Value: "5105105105105100"},
+		"15": {Network: CardNetworkUnionPay, Value: "6220000012345678"},
+	}, emvData.CardNetworkAccounts)
+	assert.Len(t, emvData.MerchantAccountInfo, 1)
+	assert.Contains(t, emvData.MerchantAccountInfo, "29")
+	assert.Equal(t, QRSchemePromptPay, emvData.QRInfo().PaymentScheme)
+	assert.NoError(t, emvData.ValidateCardNetworkAccounts())
+
+	encoded, err := emvData.Encode()
+	require.NoError(t, err)
+	assert.Equal(t, cardNetworkQR, encoded)
+}
+
+func TestEMVData_ValidateCardNetworkAccounts(t *testing.T) {
+	tests := []struct {
+		name     string
+		qrString string
+		wantErr  string
+	}{
+		{
+			name:     "Luhn failure",
+			qrString: "0002010102110216411111111111111253037645802TH630430C5",
+			wantErr:  "tag 02: invalid card network merchant account: Visa merchant PAN fails the Luhn check",
+		},
+		{
+			name:     "non-digit merchant PAN",
+			qrString: "000201010211020441X153037645802TH63046892",
+			wantErr:  "tag 02: invalid card network merchant account: Visa merchant PAN must be 8 to 19 digits",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Malformed identifiers do not fail decoding
+			emvData, err := DecodeEMVQR(tt.qrString)
+			require.NoError(t, err)
+
+			err = emvData.ValidateCardNetworkAccounts()
+			assert.ErrorIs(t, err, ErrEMVInvalidCardAccount)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCardNetworkAccount_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		account CardNetworkAccount
+		wantErr bool
+	}{
+		{name: "Visa", account: CardNetworkAccount{Network: CardNetworkVisa, Value: "4111111111111111"}},
+		{name: "Discover", account: CardNetworkAccount{Network: CardNetworkDiscover, Value: "6011111111111117"}},
+		{name: "Mastercard Luhn failure", account: CardNetworkAccount{Network: CardNetworkMastercard, Value: "5105105105105101"}, wantErr: true},
+		{name: "Visa too short", account: CardNetworkAccount{Network: CardNetworkVisa, Value: "4242"}, wantErr: true},
+		{name: "UnionPay alphanumeric", account: CardNetworkAccount{Network: CardNetworkUnionPay, Value: "ACQ0001MERCHANT"}},
+		{name: "EMVCo empty", account: CardNetworkAccount{Network: CardNetworkEMVCo}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.account.Validate()
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrEMVInvalidCardAccount)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMapCardNetwork(t *testing.T) {
+	tests := map[string]CardNetwork{
+		"02": CardNetworkVisa,
+		"05": CardNetworkMastercard,
+		"06": CardNetworkEMVCo,
+		"10": CardNetworkDiscover,
+		"11": CardNetworkAmex,
+		"14": CardNetworkJCB,
+		"16": CardNetworkUnionPay,
+		"25": CardNetworkEMVCo,
+		"26": CardNetworkUnknown,
+	}
+
+	for tag, expected := range tests {
+		assert.Equal(t, expected, mapCardNetwork(tag), tag)
+	}
+}
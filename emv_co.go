@@ -4,8 +4,8 @@
 package xstr
 
 import (
+	"errors"
 	"fmt"
-	"strconv"
 )
 
 // QRPaymentType represents the type of QR payment based on AID.
@@ -40,6 +40,7 @@ const (
 	QRSchemeDuitNow   QRPaymentScheme = "DuitNow"   // Malaysia real-time payment
 	QRSchemeUPI       QRPaymentScheme = "UPI"       // India Unified Payments Interface
 	QRSchemeNETS      QRPaymentScheme = "NETS"      // Singapore electronic payment
+	QRSchemePayNow    QRPaymentScheme = "PayNow"    // Singapore real-time payment
 	QRSchemeAlipay    QRPaymentScheme = "Alipay"    // Alipay global payment
 	QRSchemeWeChatPay QRPaymentScheme = "WeChatPay" // WeChat Pay global payment
 	QRSchemeUnknown   QRPaymentScheme = "Unknown"
@@ -58,25 +59,41 @@ type MerchantAccount struct {
 	UnresolvedData map[string]string `json:"unresolved_data"` // Other unresolved sub-fields
 }
 
+// UnreservedTemplate represents an unreserved template (tags 80-99).
+// Like merchant accounts, it carries a Globally Unique Identifier followed by
+// proprietary sub-fields defined by the owner of the GUI.
+type UnreservedTemplate struct {
+	GUI           string            `json:"gui"`            // Tag 00: Globally Unique Identifier
+	PaymentScheme QRPaymentScheme   `json:"payment_scheme"` // Mapped payment scheme (PromptPay, QRIS, etc.)
+	SubFields     map[string]string `json:"sub_fields"`     // Proprietary sub-fields 01-99
+	RawValue      string            `json:"raw_value"`      // Original raw value
+}
+
 // EMVData represents decoded EMV QR code data structure.
+// Every map field, including the UnresolvedData of each merchant account, is allocated
+// by the decoder even when the payload contains no matching tags, so callers can add
+// fields before Encode without nil checks. Use EMVTLVSeq where these maps are not needed.
 type EMVData struct {
-	PayloadFormatIndicator    string                      `json:"payload_format_indicator"`
-	PointOfInitiationMethod   string                      `json:"point_of_initiation_method"`
-	POIMethodType             POIMethodType               `json:"poi_method_type"` // Mapped POI method type (static, dynamic)
-	MerchantAccountInfo       map[string]*MerchantAccount `json:"merchant_account_info"`
-	MerchantCategoryCode      string                      `json:"merchant_category_code"`
-	TransactionCurrency       string                      `json:"transaction_currency"`
-	TransactionAmount         string                      `json:"transaction_amount"`
-	TipOrConvenienceIndicator string                      `json:"tip_or_convenience_indicator"`
-	ValueOfConvenienceFee     string                      `json:"value_of_convenience_fee"`
-	CountryCode               string                      `json:"country_code"`
-	MerchantName              string                      `json:"merchant_name"`
-	MerchantCity              string                      `json:"merchant_city"`
-	PostalCode                string                      `json:"postal_code"`
-	AdditionalData            map[string]string           `json:"additional_data"`
-	MerchantInformation       map[string]string           `json:"merchant_information"`
-	CRC                       string                      `json:"crc"`
-	UnresolvedData            map[string]string           `json:"unresolved_data"`
+	PayloadFormatIndicator    string                         `json:"payload_format_indicator"`
+	PointOfInitiationMethod   string                         `json:"point_of_initiation_method"`
+	POIMethodType             POIMethodType                  `json:"poi_method_type"`       // Mapped POI method type (static, dynamic)
+	CardNetworkAccounts       map[string]*CardNetworkAccount `json:"card_network_accounts"` // Tags 02-25: Card network merchant identifiers
+	MerchantAccountInfo       map[string]*MerchantAccount    `json:"merchant_account_info"` // Tags 26-51: GUI-based merchant accounts
+	MerchantCategoryCode      string                         `json:"merchant_category_code"`
+	TransactionCurrency       string                         `json:"transaction_currency"`
+	TransactionAmount         string                         `json:"transaction_amount"`
+	TipOrConvenienceIndicator string                         `json:"tip_or_convenience_indicator"`
+	ValueOfConvenienceFee     string                         `json:"value_of_convenience_fee"`
+	CountryCode               string                         `json:"country_code"`
+	MerchantName              string                         `json:"merchant_name"`
+	MerchantCity              string                         `json:"merchant_city"`
+	PostalCode                string                         `json:"postal_code"`
+	AdditionalData            map[string]string              `json:"additional_data"`
+	MerchantInformation       map[string]string              `json:"merchant_information"` // Tag 64: Merchant Information Language Template
+	RFUData                   map[string]string              `json:"rfu_data"`             // Tags 65-79: Reserved for future use by EMVCo
+	UnreservedTemplates       map[string]*UnreservedTemplate `json:"unreserved_templates"` // Tags 80-99
+	CRC                       string                         `json:"crc"`
+	UnresolvedData            map[string]string              `json:"unresolved_data"`
 }
 
 // EMVDataValue represents a single EMV data field with tag, length, and value.
@@ -88,119 +105,57 @@ type EMVDataValue struct {
 
 // DecodeEMVQR decodes EMV QR code string and returns structured data.
 // It parses the TLV (Tag-Length-Value) format according to EMV QR Code specification.
+// The QR string must end with a valid CRC field (tag 63); see DecodeEMVQRLenient
+// for payloads that may omit it, and NewDecoder for configurable decoding.
+// Payloads longer than EMVMaxPayloadLength are rejected. DecodeEMVQR never panics,
+// so it is safe to call on untrusted camera or user input.
 func DecodeEMVQR(qrString string) (*EMVData, error) {
-	if len(qrString) < 4 {
-		return nil, fmt.Errorf("invalid EMV QR code: too short")
-	}
-
-	emvData := &EMVData{
-		MerchantAccountInfo: make(map[string]*MerchantAccount),
-		AdditionalData:      make(map[string]string),
-		MerchantInformation: make(map[string]string),
-		UnresolvedData:      make(map[string]string),
-	}
-
-	// Parse TLV data sequentially from QR string
-	position := 0
-	for position < len(qrString) {
-		if position+4 > len(qrString) {
-			break
-		}
-
-		// Parse tag (2 digits)
-		tag := qrString[position : position+2]
-		position += 2
-
-		// Parse length (2 digits)
-		lengthStr := qrString[position : position+2]
-		position += 2
-
-		length, err := strconv.Atoi(lengthStr)
-		if err != nil {
-			return nil, fmt.Errorf("invalid length at position %d: %s", position-2, lengthStr)
-		}
-
-		if position+length > len(qrString) {
-			return nil, fmt.Errorf("invalid data length at tag %s", tag)
-		}
-
-		// Parse value
-		value := qrString[position : position+length]
-		position += length
-
-		// Map to appropriate field
-		if err := mapEMVField(emvData, tag, value); err != nil {
-			return nil, fmt.Errorf("error mapping field %s: %v", tag, err)
-		}
-	}
-
-	// Validate CRC checksum to ensure data integrity
-	// EMV standard requires CRC-16 validation on complete QR data
-	if emvData.CRC != "" {
-		// Reconstruct original data for CRC calculation by removing actual CRC value
-		// and appending dummy CRC tag "6304" as per EMV specification
-		crcTagPosition := len(qrString) - 8 // CRC is always 8 chars: "6304" + 4-digit CRC
-		if crcTagPosition >= 0 && qrString[crcTagPosition:crcTagPosition+4] == "6304" {
-			dataForCRC := qrString[:crcTagPosition] + "6304"
-			calculatedCRC := calculateCRC16(dataForCRC)
-
-			if emvData.CRC != calculatedCRC {
-				return nil, fmt.Errorf("invalid CRC: expected %s, got %s", calculatedCRC, emvData.CRC)
-			}
-		} else {
-			return nil, fmt.Errorf("invalid EMV QR format: CRC tag not found at expected position")
-		}
-	}
+	return defaultDecoder.Decode(qrString)
+}
 
-	return emvData, nil
+// DecodeEMVQRLenient decodes EMV QR code string like DecodeEMVQR,
+// but accepts payloads without a CRC field. A CRC field that is present
+// must still be the last field and hold the correct checksum.
+func DecodeEMVQRLenient(qrString string) (*EMVData, error) {
+	return lenientDecoder.Decode(qrString)
 }
 
 // ParseEMVTLV parses EMV QR code string into individual TLV structures.
 // Returns a slice of EMVDataValue representing each tag-length-value triplet.
+// Use EMVTLVSeq to iterate the same fields without allocating.
+// Templates are not expanded, so work is linear in the input length; it never panics.
 func ParseEMVTLV(qrString string) ([]EMVDataValue, error) {
 	if len(qrString) < 4 {
-		return nil, fmt.Errorf("invalid EMV QR code: too short")
+		return nil, ErrEMVTooShort
 	}
 
-	var tlvData []EMVDataValue
-	position := 0
-
-	for position < len(qrString) {
-		if position+4 > len(qrString) {
+	// Count fields first so the slice is allocated once
+	count := 0
+	for _, err := range EMVTLVSeq(qrString) {
+		if err != nil {
 			break
 		}
+		count++
+	}
 
-		// Parse tag (2 digits)
-		tag := qrString[position : position+2]
-		position += 2
-
-		// Parse length (2 digits)
-		lengthStr := qrString[position : position+2]
-		position += 2
-
-		length, err := strconv.Atoi(lengthStr)
-		if err != nil {
-			return nil, fmt.Errorf("invalid length at position %d: %s", position-2, lengthStr)
+	tlvData := make([]EMVDataValue, 0, count)
+	for field, err := range EMVTLVSeq(qrString) {
+		if errors.Is(err, ErrEMVTrailingData) {
+			break
 		}
-
-		if position+length > len(qrString) {
-			return nil, fmt.Errorf("invalid data length at tag %s", tag)
+		if err != nil {
+			return nil, err
 		}
-
-		// Parse value
-		value := qrString[position : position+length]
-		position += length
-
-		tlvData = append(tlvData, EMVDataValue{
-			Tag:    tag,
-			Length: length,
-			Value:  value,
-		})
+		tlvData = append(tlvData, field)
 	}
 
 	return tlvData, nil
 }
 
+// errEMVMalformedTemplate is returned by mapEMVField for an unreserved template (tags 80-99)
+// whose value is not TLV. The raw value is stored anyway, so lenient decoding can continue.
+var errEMVMalformedTemplate = errors.New("error parsing unreserved template")
+
 // mapEMVField maps EMV tag to appropriate struct field.
 func mapEMVField(emvData *EMVData, tag, value string) error {
 	switch tag {
@@ -236,28 +191,48 @@ func mapEMVField(emvData *EMVData, tag, value string) error {
 		emvData.AdditionalData = subFields
 	case "63":
 		emvData.CRC = value
+	case "64":
+		setMapField(&emvData.MerchantInformation, tag, value)
 	default:
-		// Handle merchant account information (tags 02-51)
-		// These tags contain payment provider specific data
-		if tag >= "02" && tag <= "51" {
+		// Tags 02-25 hold primitive card network merchant identifiers, not templates
+		if tag >= "02" && tag <= "25" {
+			if emvData.CardNetworkAccounts == nil {
+				emvData.CardNetworkAccounts = make(map[string]*CardNetworkAccount)
+			}
+			emvData.CardNetworkAccounts[tag] = &CardNetworkAccount{Network: mapCardNetwork(tag), Value: value}
+		} else if tag >= "26" && tag <= "51" {
+			// Handle merchant account information (tags 26-51)
+			// These tags contain payment provider specific data
 			// Parse merchant account sub-fields
 			merchantAccount, err := parseMerchantAccountInfo(value)
 			if err != nil {
 				return fmt.Errorf("error parsing merchant account info: %v", err)
 			}
 			merchantAccount.RawValue = value
+			if emvData.MerchantAccountInfo == nil {
+				emvData.MerchantAccountInfo = make(map[string]*MerchantAccount)
+			}
 			emvData.MerchantAccountInfo[tag] = merchantAccount
-		} else if tag >= "64" && tag <= "99" {
-			// Merchant Information Language Template or RFU
-			if tag == "99" {
-				// This is actually unresolved/future use
-				emvData.UnresolvedData[tag] = value
-			} else {
-				emvData.MerchantInformation[tag] = value
+		} else if tag >= "65" && tag <= "79" {
+			// Reserved for future use by EMVCo
+			setMapField(&emvData.RFUData, tag, value)
+		} else if tag >= "80" && tag <= "99" {
+			// Unreserved templates are GUI-based like merchant accounts. Proprietary data that
+			// is not TLV is kept raw, and the error is only fatal with strict tag handling.
+			template, err := parseUnreservedTemplate(value)
+			if err != nil {
+				template = &UnreservedTemplate{PaymentScheme: QRSchemeUnknown, RawValue: value}
+			}
+			if emvData.UnreservedTemplates == nil {
+				emvData.UnreservedTemplates = make(map[string]*UnreservedTemplate)
+			}
+			emvData.UnreservedTemplates[tag] = template
+			if err != nil {
+				return fmt.Errorf("%w: %v", errEMVMalformedTemplate, err)
 			}
 		} else {
 			// Store unresolved data
-			emvData.UnresolvedData[tag] = value
+			setMapField(&emvData.UnresolvedData, tag, value)
 		}
 	}
 
@@ -267,88 +242,134 @@ func mapEMVField(emvData *EMVData, tag, value string) error {
 // parseSubFields parses sub-fields within a TLV structure.
 func parseSubFields(data string) (map[string]string, error) {
 	subFields := make(map[string]string)
-	position := 0
 
-	for position < len(data) {
-		if position+4 > len(data) {
+	for field, err := range EMVTLVSeq(data) {
+		if errors.Is(err, ErrEMVTrailingData) {
 			break
 		}
-
-		// Parse tag (2 digits)
-		tag := data[position : position+2]
-		position += 2
-
-		// Parse length (2 digits)
-		lengthStr := data[position : position+2]
-		position += 2
-
-		length, err := strconv.Atoi(lengthStr)
 		if err != nil {
-			return nil, fmt.Errorf("invalid sub-field length: %s", lengthStr)
-		}
-
-		if position+length > len(data) {
-			return nil, fmt.Errorf("invalid sub-field data length at tag %s", tag)
+			return nil, subFieldError(err)
 		}
-
-		// Parse value
-		value := data[position : position+length]
-		position += length
-
-		subFields[tag] = value
+		subFields[field.Tag] = field.Value
 	}
 
 	return subFields, nil
 }
 
+// subFieldError rewords a TLV iteration error for sub-field templates.
+func subFieldError(err error) error {
+	var tlvErr *TLVError
+	if errors.As(err, &tlvErr) && tlvErr.Err == ErrEMVInvalidLength {
+		return fmt.Errorf("invalid sub-field length: %s", tlvErr.Length)
+	}
+	if tlvErr != nil {
+		return fmt.Errorf("invalid sub-field data length at tag %s", tlvErr.Tag)
+	}
+	return err
+}
+
 // parseMerchantAccountInfo parses merchant account information sub-fields.
 // Returns a MerchantAccount struct with parsed sub-fields according to EMV specification.
 func parseMerchantAccountInfo(data string) (*MerchantAccount, error) {
-	account := &MerchantAccount{
-		UnresolvedData: make(map[string]string),
-	}
-
-	subFields, err := parseSubFields(data)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse merchant account sub-fields: %v", err)
-	}
+	account := &MerchantAccount{}
 
 	// Map known sub-fields to struct properties
 	// Each payment scheme may use different sub-field combinations
-	for tag, value := range subFields {
-		switch tag {
+	for field, err := range EMVTLVSeq(data) {
+		if errors.Is(err, ErrEMVTrailingData) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse merchant account sub-fields: %v", subFieldError(err))
+		}
+
+		switch field.Tag {
 		case "00":
 			// AID (Application Identifier) determines payment scheme and type
-			account.AID = value
-			account.AIDType = mapAIDType(value)
-			account.PaymentScheme = mapScheme(value)
+			account.AID = field.Value
+			account.AIDType = mapAIDType(field.Value)
+			account.PaymentScheme = mapScheme(field.Value)
 		case "01":
-			account.MerchantID = value
+			account.MerchantID = field.Value
 		case "02":
-			account.Reference1 = value
+			account.Reference1 = field.Value
 		case "03":
-			account.Reference2 = value
+			account.Reference2 = field.Value
 		case "04":
-			account.Reference3 = value
+			account.Reference3 = field.Value
 		default:
 			// Store unknown sub-fields
-			account.UnresolvedData[tag] = value
+			setMapField(&account.UnresolvedData, field.Tag, field.Value)
 		}
 	}
 
 	return account, nil
 }
 
-// calculateCRC16 calculates CRC-16 checksum for EMV QR code validation.
-// Uses CRC-16-CCITT polynomial: 0x1021 with EMV QR Code specific parameters:
-// Initial value: 0xFFFF, Final XOR: 0x0000, Reflect input/output: false
-func calculateCRC16(data string) string {
-	crc := uint16(0xFFFF)
+// parseUnreservedTemplate parses the GUI and proprietary sub-fields of an unreserved template.
+// The GUI is mapped to a payment scheme with the same registry as merchant accounts.
+func parseUnreservedTemplate(data string) (*UnreservedTemplate, error) {
+	subFields, err := parseSubFields(data)
+	if err != nil {
+		return nil, err
+	}
 
-	// Process each byte using CRC-16-CCITT polynomial
-	for i := 0; i < len(data); i++ {
-		crc ^= uint16(data[i]) << 8
+	template := &UnreservedTemplate{
+		GUI:      subFields["00"],
+		RawValue: data,
+	}
+	template.PaymentScheme = mapScheme(template.GUI)
+	delete(subFields, "00")
+	if len(subFields) > 0 {
+		template.SubFields = subFields
+	}
+	return template, nil
+}
 
+// allocateMaps allocates the map fields decoded data always carries, so callers can add
+// fields before Encode. Fields are stored with setMapField while decoding.
+func (e *EMVData) allocateMaps() {
+	if e.CardNetworkAccounts == nil {
+		e.CardNetworkAccounts = make(map[string]*CardNetworkAccount)
+	}
+	if e.MerchantAccountInfo == nil {
+		e.MerchantAccountInfo = make(map[string]*MerchantAccount)
+	}
+	if e.AdditionalData == nil {
+		e.AdditionalData = make(map[string]string)
+	}
+	if e.MerchantInformation == nil {
+		e.MerchantInformation = make(map[string]string)
+	}
+	if e.RFUData == nil {
+		e.RFUData = make(map[string]string)
+	}
+	if e.UnreservedTemplates == nil {
+		e.UnreservedTemplates = make(map[string]*UnreservedTemplate)
+	}
+	if e.UnresolvedData == nil {
+		e.UnresolvedData = make(map[string]string)
+	}
+	for _, account := range e.MerchantAccountInfo {
+		if account != nil && account.UnresolvedData == nil {
+			account.UnresolvedData = make(map[string]string)
+		}
+	}
+}
+
+// setMapField stores a value, allocating the map on first use
+// so it also works on EMVData built without the decoder.
+func setMapField(m *map[string]string, key, value string) {
+	if *m == nil {
+		*m = make(map[string]string)
+	}
+	(*m)[key] = value
+}
+
+// crc16Table holds the precomputed CRC-16-CCITT (0x1021) remainder for every byte value.
+var crc16Table = func() (table [256]uint16) {
+	for i := range table {
+		crc := uint16(i) << 8
 		// Process each bit using polynomial 0x1021
 		for j := 0; j < 8; j++ {
 			if crc&0x8000 != 0 {
@@ -357,10 +378,18 @@ func calculateCRC16(data string) string {
 				crc = crc << 1
 			}
 		}
+		table[i] = crc
 	}
+	return table
+}()
 
-	// Final XOR with 0x0000 (no change) as per EMV specification
-	return fmt.Sprintf("%04X", crc&0xFFFF)
+// updateCRC16 continues a CRC-16-CCITT calculation over data,
+// so a payload and its CRC header can be checksummed without concatenation.
+func updateCRC16(crc uint16, data string) uint16 {
+	for i := 0; i < len(data); i++ {
+		crc = crc<<8 ^ crc16Table[byte(crc>>8)^data[i]]
+	}
+	return crc
 }
 
 // mapScheme determines payment network from AID/GUI identifier.
@@ -380,6 +409,8 @@ func mapScheme(gui string) QRPaymentScheme {
 		return QRSchemeUPI
 	case "COM.SG.NETS":
 		return QRSchemeNETS
+	case "SG.PAYNOW":
+		return QRSchemePayNow
 	case "COM.ALIPAY.WWW":
 		return QRSchemeAlipay
 	case "COM.WECHAT.WWW":
@@ -439,12 +470,6 @@ type QRInfo struct {
 // This method prioritizes merchant accounts and provides unified access to key QR data
 // for business logic and payment processing.
 func (e *EMVData) QRInfo() QRInfo {
-	info := QRInfo{
-		POIMethodType:     e.POIMethodType,
-		TransactionAmount: e.TransactionAmount,
-		CountryCode:       e.CountryCode,
-	}
-
 	// Find primary merchant account (prefer lower tag numbers as they're typically primary)
 	var primaryAccount *MerchantAccount
 
@@ -462,7 +487,7 @@ func (e *EMVData) QRInfo() QRInfo {
 	// Second pass: if no preferred tag found, use first available
 	if primaryAccount == nil {
 		for tag, account := range e.MerchantAccountInfo {
-			if tag >= "02" && tag <= "51" {
+			if tag >= "26" && tag <= "51" {
 				primaryAccount = account
 				break
 			}
@@ -471,18 +496,32 @@ func (e *EMVData) QRInfo() QRInfo {
 
 	// Extract information from primary account
 	if primaryAccount != nil {
-		info.AID = primaryAccount.AID
-		info.AIDType = primaryAccount.AIDType
-		info.PaymentScheme = primaryAccount.PaymentScheme
-		info.MerchantID = primaryAccount.MerchantID
-		info.Reference1 = primaryAccount.Reference1
-		info.Reference2 = primaryAccount.Reference2
-		info.Reference3 = primaryAccount.Reference3
+		return e.qrInfoFromAccount(primaryAccount)
+	}
 
-		// Fill missing references with additional data if available
-		fillMissingReferences(&info, e.AdditionalData)
+	return QRInfo{
+		POIMethodType:     e.POIMethodType,
+		TransactionAmount: e.TransactionAmount,
+		CountryCode:       e.CountryCode,
 	}
+}
 
+// qrInfoFromAccount builds the QRInfo of a merchant account, filling missing
+// references with additional data if available.
+func (e *EMVData) qrInfoFromAccount(account *MerchantAccount) QRInfo {
+	info := QRInfo{
+		AID:               account.AID,
+		AIDType:           account.AIDType,
+		POIMethodType:     e.POIMethodType,
+		PaymentScheme:     account.PaymentScheme,
+		TransactionAmount: e.TransactionAmount,
+		CountryCode:       e.CountryCode,
+		MerchantID:        account.MerchantID,
+		Reference1:        account.Reference1,
+		Reference2:        account.Reference2,
+		Reference3:        account.Reference3,
+	}
+	fillMissingReferences(&info, e.AdditionalData)
 	return info
 }
 
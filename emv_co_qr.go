@@ -2,20 +2,19 @@ package xstr
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
-
-	"github.com/sigurn/crc16"
 )
 
-// EMVCoQRInfo holds parsed EMVCo QR code information
+// EMVCoQRInfo holds parsed EMVCo QR code information.
+// It is a simplified Thai PromptPay view derived from the EMVData produced by DecodeEMVQR.
 type EMVCoQRInfo struct {
 	Format          string
 	MerchantAccount string
 	Amount          string
-	PhoneNumber     string
+	PhoneNumber     string // Tag 29 proxy; mobile numbers without the leading "00", e.g. "66812345678"
 	CountryCode     string
 	Crc             string
+	ProxyType       PromptPayProxyType // Type of the tag 29 proxy held in PhoneNumber
 	CurrencyISO4217 string
 	BillerID        string
 	Ref1            string
@@ -23,99 +22,77 @@ type EMVCoQRInfo struct {
 	Ref3            string
 }
 
-var crc16Table *crc16.Table
-
-func init() {
-	crc16Table = crc16.MakeTable(crc16.CRC16_CCITT_FALSE)
-}
-
+// validateEMVCoQRString requires a terminal CRC tag and validates its checksum.
+// It uses VerifyEMVCRC like DecodeEMVQR, so both parsers return the same CRC errors.
 func validateEMVCoQRString(qrString string) error {
 	if len(qrString) < 14 {
 		return fmt.Errorf("qr string too short")
 	}
-	data := []byte(qrString[:len(qrString)-4])
-	crc := crc16.Checksum(data, crc16Table)
-	calculatedCRC := fmt.Sprintf("%04X", int(crc))
-	expectedCRC := qrString[len(qrString)-4:]
-	if calculatedCRC != expectedCRC {
-		return fmt.Errorf("invalid crc: expected %s, got %s", expectedCRC, calculatedCRC)
+	if err := VerifyEMVCRC(qrString); err != nil {
+		return fmt.Errorf("invalid crc: %w", err)
 	}
 	return nil
 }
 
+// ParseEMVCoQRString parses a PromptPay EMVCo QR string into EMVCoQRInfo.
+// The QR string must end with a valid CRC tag; the payload is decoded with DecodeEMVQR.
+// Like DecodeEMVQR it never panics on malformed input.
 func ParseEMVCoQRString(qrString string) (*EMVCoQRInfo, error) {
 	if err := validateEMVCoQRString(qrString); err != nil {
 		return nil, err
 	}
-	result := &EMVCoQRInfo{}
-	index := 0
-	for index < len(qrString) {
-		if index+4 > len(qrString) {
-			return nil, fmt.Errorf("invalid qr structure")
-		}
-		id := qrString[index : index+2]
-		length, err := strconv.Atoi(qrString[index+2 : index+4])
-		if err != nil {
-			return nil, fmt.Errorf("invalid qr structure")
-		}
-		if index+4+length > len(qrString) {
-			return nil, fmt.Errorf("invalid specified qr string length")
-		}
-		value := qrString[index+4 : index+4+length]
-		switch id {
-		case "01":
-			result.Format = value
-		case "29":
-			prefixPhoneIndex := strings.Index(value, "011300")
-			result.MerchantAccount = value
-			if prefixPhoneIndex != -1 {
-				result.PhoneNumber = value[prefixPhoneIndex+6:]
-			} else {
-				prefixIDIndex := strings.Index(value, "110213")
-				if prefixIDIndex != -1 {
-					result.PhoneNumber = value[prefixIDIndex+6:]
-				}
-			}
-		case "30":
-			result.MerchantAccount = value
-			index2 := 0
-			for index2 < len(value) {
-				if index2+4 > len(value) {
-					return nil, fmt.Errorf("invalid qr structure")
-				}
-				id2 := value[index2 : index2+2]
-				length2, err := strconv.Atoi(value[index2+2 : index2+4])
-				if err != nil {
-					return nil, fmt.Errorf("invalid qr structure")
-				}
-				if index2+4+length2 > len(value) {
-					return nil, fmt.Errorf("invalid specified qr string length")
-				}
-				value2 := value[index2+4 : index2+4+length2]
-				switch id2 {
-				case "01":
-					result.BillerID = value2
-				case "02":
-					result.Ref1 = value2
-				case "03":
-					result.Ref2 = value2
-				}
-				index2 += 4 + length2
-			}
-		case "54":
-			result.Amount = value
-		case "58":
-			result.CountryCode = value
-		case "62":
-			if len(value) > 4 {
-				result.Ref3 = value[4:]
-			}
-		case "63":
-			result.Crc = value
-		case "53":
-			result.CurrencyISO4217 = value
+	emvData, err := DecodeEMVQR(qrString)
+	if err != nil {
+		return nil, err
+	}
+	info := emvData.EMVCoQRInfo()
+	return &info, nil
+}
+
+// EMVCoQRInfo derives the PromptPay view from decoded EMV data.
+// Tag 29 proxies are identified by sub-tag (01 mobile, 02 national ID, 03 e-wallet,
+// 04 bank account), tag 30 provides biller ID and references, and Ref3 comes from
+// tag 62 sub-fields.
+func (e *EMVData) EMVCoQRInfo() EMVCoQRInfo {
+	info := EMVCoQRInfo{
+		Format:          e.PointOfInitiationMethod,
+		Amount:          e.TransactionAmount,
+		CountryCode:     e.CountryCode,
+		Crc:             e.CRC,
+		CurrencyISO4217: e.TransactionCurrency,
+	}
+
+	// Tag 29: PromptPay credit transfer, proxy sub-tags are mutually exclusive
+	if account, exists := e.MerchantAccountInfo["29"]; exists {
+		info.MerchantAccount = account.RawValue
+		switch {
+		case account.MerchantID != "":
+			// Mobile proxies keep the format of earlier releases: "0066812345678" -> "66812345678"
+			info.PhoneNumber, info.ProxyType = strings.TrimPrefix(account.MerchantID, "00"), PromptPayProxyMobile
+		case account.Reference1 != "":
+			info.PhoneNumber, info.ProxyType = account.Reference1, PromptPayProxyNationalID
+		case account.Reference2 != "":
+			info.PhoneNumber, info.ProxyType = account.Reference2, PromptPayProxyEWallet
+		case account.Reference3 != "":
+			info.PhoneNumber, info.ProxyType = account.Reference3, PromptPayProxyBankAccount
 		}
-		index += 4 + length
 	}
-	return result, nil
+
+	// Tag 30: PromptPay bill payment
+	if account, exists := e.MerchantAccountInfo["30"]; exists {
+		info.MerchantAccount = account.RawValue
+		info.BillerID = account.MerchantID
+		info.Ref1 = account.Reference1
+		info.Ref2 = account.Reference2
+	}
+
+	// Tag 62: Thai bill payment carries Ref3 in the terminal label (07),
+	// falling back to the reference label (05)
+	if ref3, exists := e.AdditionalData["07"]; exists {
+		info.Ref3 = ref3
+	} else {
+		info.Ref3 = e.AdditionalData["05"]
+	}
+
+	return info
 }
@@ -278,3 +278,127 @@ func TestEMVCoQRInfo_StructFields(t *testing.T) {
 	assert.NotNil(t, result.Ref2)
 	assert.NotNil(t, result.Ref3)
 }
+
+func TestParseEMVCoQRString_PromptPay(t *testing.T) {
+	tests := []struct {
+		name     string
+		qrString string
+		expected EMVCoQRInfo
+	}{
+		{
+			name:     "tag 29 mobile proxy",
+			qrString: "00020101021129370016A000000677010111011300668123456785802TH5303764540510.0063044ABE",
+			expected: EMVCoQRInfo{
+				Format:          "11",
+				MerchantAccount: "0016A00000067701011101130066812345678",
+				Amount:          "10.00",
+				PhoneNumber:     "66812345678", // Leading "00" dropped as in earlier releases
+				ProxyType:       PromptPayProxyMobile,
+				CountryCode:     "TH",
+				Crc:             "4ABE",
+				CurrencyISO4217: "764",
+			},
+		},
+		{
+			name:     "tag 29 national ID proxy",
+			qrString: "00020101021129370016A000000677010111021312345678901235802TH53037646304EC40",
+			expected: EMVCoQRInfo{
+				Format:          "11",
+				MerchantAccount: "0016A00000067701011102131234567890123",
+				PhoneNumber:     "1234567890123",
+				ProxyType:       PromptPayProxyNationalID,
+				CountryCode:     "TH",
+				Crc:             "EC40",
+				CurrencyISO4217: "764",
+			},
+		},
+		{
+			name:     "tag 29 bank account proxy",
+			qrString: "00020101021129340016A000000677010111041012345678905802TH530376463041325",
+			expected: EMVCoQRInfo{
+				Format:          "11",
+				MerchantAccount: "0016A00000067701011104101234567890",
+				PhoneNumber:     "1234567890",
+				ProxyType:       PromptPayProxyBankAccount,
+				CountryCode:     "TH",
+				Crc:             "1325",
+				CurrencyISO4217: "764",
+			},
+		},
+		{
+			name:     "tag 30 bill payment with tag 62 sub-fields",
+			qrString: "00020101021230590016A000000677010112011501075370008820502061234560306ABCDEF530376454031005802TH62210105INV010708TERM00016304C43B",
+			expected: EMVCoQRInfo{
+				Format:          "12",
+				MerchantAccount: "0016A000000677010112011501075370008820502061234560306ABCDEF",
+				Amount:          "100",
+				CountryCode:     "TH",
+				Crc:             "C43B",
+				CurrencyISO4217: "764",
+				BillerID:        "010753700088205",
+				Ref1:            "123456",
+				Ref2:            "ABCDEF",
+				Ref3:            "TERM0001",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseEMVCoQRString(tt.qrString)
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			assert.Equal(t, tt.expected, *result)
+
+			// Both parsers must agree on the same payload
+			emvData, err := DecodeEMVQR(tt.qrString)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, emvData.EMVCoQRInfo())
+		})
+	}
+}
+
+func TestParseEMVCoQRString_CRCTagRequired(t *testing.T) {
+	// Valid CRC over the whole string, but the last field is not tag 63
+	result, err := ParseEMVCoQRString("0102015802TH5303764")
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "invalid crc")
+}
+
+func TestParseEMVCoQRString_CRCErrorsMatchDecodeEMVQR(t *testing.T) {
+	tests := []struct {
+		name     string
+		qrString string
+		wantErr  error
+	}{
+		{"wrong CRC", "010201630441C6", ErrEMVCRCMismatch},
+		{"no CRC field", "0102015802TH5303764", ErrEMVCRCNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseEMVCoQRString(tt.qrString)
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			_, err = DecodeEMVQR(tt.qrString)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func FuzzParseEMVCoQRString(f *testing.F) {
+	f.Add("010201630441C5")
+	f.Add("010201630441C6")
+	f.Add("00020101021129370016A000000677010111011300668123456785802TH5303764540510.0063044ABE")
+	f.Add("00020101021230590016A000000677010112011501075370008820502061234560306ABCDEF530376454031005802TH62210105INV010708TERM00016304C43B")
+	f.Add("01999912345630412AB")
+	f.Add("01XX123456304ABCD")
+
+	f.Fuzz(func(t *testing.T, qrString string) {
+		result, err := ParseEMVCoQRString(qrString)
+		if err == nil && result == nil {
+			t.Fatal("nil result without error")
+		}
+	})
+}
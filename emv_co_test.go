@@ -2,6 +2,7 @@ package xstr
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -192,9 +193,8 @@ func TestParseEMVTLV(t *testing.T) {
 	}
 }
 
-func TestCalculateCRC16(t *testing.T) {
-	// This function tests the internal calculateCRC16 function
-	// We'll compute the actual CRC values and update expectations based on results
+func TestComputeEMVCRCFormat(t *testing.T) {
+	// Inputs end with the CRC header, which ComputeEMVCRC appends itself
 	tests := []struct {
 		name  string
 		input string
@@ -219,7 +219,7 @@ func TestCalculateCRC16(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := calculateCRC16(tt.input)
+			result := ComputeEMVCRC(strings.TrimSuffix(tt.input, emvCRCTag))
 			// Print the actual result for now so we can update expectations
 			t.Logf("CRC for %q: %s", tt.input, result)
 			// For now, just check that we get a 4-character hex string
@@ -288,12 +288,51 @@ func TestMapEMVField(t *testing.T) {
 			},
 		},
 		{
-			name:    "unresolved data",
+			name:    "unreserved template",
+			tag:     "99",
+			value:   "0014ID.CO.QRIS.WWW0105test1",
+			wantErr: false,
+			validateMap: func(t *testing.T, emvData *EMVData) {
+				template := emvData.UnreservedTemplates["99"]
+				require.NotNil(t, template)
+				assert.Equal(t, "ID.CO.QRIS.WWW", template.GUI)
+				assert.Equal(t, QRSchemeQRIS, template.PaymentScheme)
+				assert.Equal(t, map[string]string{"01": "test1"}, template.SubFields)
+				assert.Equal(t, "0014ID.CO.QRIS.WWW0105test1", template.RawValue)
+			},
+		},
+		{
+			name:    "malformed unreserved template",
 			tag:     "99",
 			value:   "test",
+			wantErr: true,
+		},
+		{
+			name:    "merchant information language template",
+			tag:     "64",
+			value:   "0002TH",
 			wantErr: false,
 			validateMap: func(t *testing.T, emvData *EMVData) {
-				assert.Equal(t, "test", emvData.UnresolvedData["99"]) // Tag 99 goes to UnresolvedData per our logic
+				assert.Equal(t, "0002TH", emvData.MerchantInformation["64"])
+			},
+		},
+		{
+			name:    "RFU data",
+			tag:     "65",
+			value:   "test",
+			wantErr: false,
+			validateMap: func(t *testing.T, emvData *EMVData) {
+				assert.Equal(t, "test", emvData.RFUData["65"])
+				assert.Empty(t, emvData.MerchantInformation)
+			},
+		},
+		{
+			name:    "unresolved data",
+			tag:     "57",
+			value:   "test",
+			wantErr: false,
+			validateMap: func(t *testing.T, emvData *EMVData) {
+				assert.Equal(t, "test", emvData.UnresolvedData["57"])
 			},
 		},
 	}
@@ -429,3 +468,124 @@ func TestEMVData_Integration(t *testing.T) {
 		})
 	}
 }
+
+func FuzzDecodeEMVQR(f *testing.F) {
+	seeds := []string{
+		"00020101021130750016A00000067701011201150107537000882050219ZY010556UP8013305E80309MDMBEN38J53037645406900.045802TH622407200000yJMlWBD1ltXF6zJf6304858E",
+		"00020101021230870016A00000067701011201150205565052805020220ZYZRM7LJKIHW852LI6BJ0320LV182T0VX97RFFYNH7LK530376454031005802TH62240720PQRMGGT5EFY77KDP2QDI6304DBCF",
+		"00020101021229370016A000000677010111021302455640030965802TH530376454071000.886304713E",
+		"00020101021229370016A000000677010111021302455640030965802TH530376454071000.886304FFFF",
+		"",
+		"00",
+		"00XX01",
+		"001001",
+		"00-1",
+		"2699" + strings.Repeat("0", 99),
+	}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	decoders := []*Decoder{
+		defaultDecoder,
+		NewDecoder(WithLenientCRC(), WithStrictTags()),
+		NewDecoder(WithTrimWhitespace(), WithStripZeroWidth(), WithRequiredSchemes(QRSchemePromptPay)),
+	}
+
+	f.Fuzz(func(t *testing.T, qrString string) {
+		for _, decoder := range decoders {
+			emvData, err := decoder.Decode(qrString)
+			if err != nil {
+				if emvData != nil {
+					t.Fatalf("non-nil data with error %v", err)
+				}
+				continue
+			}
+			// Views derived from decoded data must not panic either
+			_ = emvData.QRInfo()
+			_ = emvData.EMVCoQRInfo()
+			_, _ = emvData.PromptPayProxy()
+		}
+	})
+}
+
+func FuzzParseEMVTLV(f *testing.F) {
+	f.Add("00020101021130750016A00000067701011201150107537000882050219ZY010556UP8013305E80309MDMBEN38J53037645406900.045802TH622407200000yJMlWBD1ltXF6zJf6304858E")
+	f.Add("00XX01")
+	f.Add("001001")
+	f.Add("0000")
+	f.Add("000201010")
+
+	f.Fuzz(func(t *testing.T, qrString string) {
+		tlvData, err := ParseEMVTLV(qrString)
+		if err != nil {
+			return
+		}
+		// Every parsed field must be a faithful view of the input
+		for _, tlv := range tlvData {
+			if tlv.Length != len(tlv.Value) {
+				t.Fatalf("length %d does not match value %q", tlv.Length, tlv.Value)
+			}
+		}
+	})
+}
+
+func TestDecodeEMVQR_MapsAllocated(t *testing.T) {
+	emvData, err := DecodeEMVQR("00020101021129370016A0000006770101110113006681234567853037645802TH6304823E")
+	require.NoError(t, err)
+
+	// Every map field is allocated, whichever tags the payload carries
+	assert.NotNil(t, emvData.CardNetworkAccounts)
+	assert.NotNil(t, emvData.RFUData)
+	assert.NotNil(t, emvData.UnreservedTemplates)
+
+	// Decoded data can be extended before re-encoding without nil checks
+	emvData.AdditionalData["05"] = "INV001"
+	emvData.MerchantInformation["64"] = "0002TH0104SHOP"
+	emvData.UnresolvedData["57"] = "X"
+	emvData.MerchantAccountInfo["29"].UnresolvedData["05"] = "Y"
+
+	encoded, err := emvData.Encode()
+	require.NoError(t, err)
+	redecoded, err := DecodeEMVQR(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, "INV001", redecoded.AdditionalData["05"])
+	assert.Equal(t, "Y", redecoded.MerchantAccountInfo["29"].UnresolvedData["05"])
+}
+
+func TestDecodeEMVQR_UnreservedTemplates(t *testing.T) {
+	emvData, err := DecodeEMVQR("00020101021129370016A0000006770101110113006681234567853037645802TH64140002TH0104SHOP6504RFU180350014ID.CO.QRIS.WWW0106ID10200203UMI6304BB54")
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"64": "0002TH0104SHOP"}, emvData.MerchantInformation)
+	assert.Equal(t, map[string]string{"65": "RFU1"}, emvData.RFUData)
+	assert.Empty(t, emvData.UnresolvedData)
+	assert.Equal(t, map[string]*UnreservedTemplate{
+		"80": {
+			GUI:           "ID.CO.QRIS.WWW",
+			PaymentScheme: QRSchemeQRIS,
+			SubFields:     map[string]string{"01": "ID1020", "02": "UMI"},
+			RawValue:      "0014ID.CO.QRIS.WWW0106ID10200203UMI",
+		},
+	}, emvData.UnreservedTemplates)
+	assert.Equal(t, QRSchemePromptPay, emvData.QRInfo().PaymentScheme)
+}
+
+func TestDecodeEMVQR_MalformedUnreservedTemplate(t *testing.T) {
+	const qrString = "00020101021129370016A0000006770101110113006681234567853037645802TH9914ACME-LOYALTY-7630431A8"
+
+	emvData, err := DecodeEMVQR(qrString)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*UnreservedTemplate{
+		"99": {PaymentScheme: QRSchemeUnknown, RawValue: "ACME-LOYALTY-7"},
+	}, emvData.UnreservedTemplates)
+	assert.Equal(t, QRSchemePromptPay, emvData.QRInfo().PaymentScheme)
+
+	encoded, err := emvData.Encode()
+	require.NoError(t, err)
+	assert.Equal(t, qrString, encoded)
+
+	_, err = NewDecoder(WithStrictTags()).Decode(qrString)
+	assert.ErrorIs(t, err, ErrEMVInvalidField)
+	assert.ErrorContains(t, err, "error parsing unreserved template")
+}
@@ -0,0 +1,166 @@
+package xstr
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Common EMV CPM errors.
+var (
+	ErrCPMInvalidEncoding = errors.New("invalid EMV CPM QR code: invalid base64")
+	ErrCPMNoApplication   = errors.New("invalid EMV CPM QR code: no application template")
+)
+
+// cpmFormatIndicatorTag is the BER tag of the CPM payload format indicator ("CPV01").
+const cpmFormatIndicatorTag = "85"
+
+// CPMData represents a decoded EMV Consumer-Presented Mode QR code.
+// Binary values are represented as uppercase hexadecimal strings.
+type CPMData struct {
+	PayloadFormatIndicator string            `json:"payload_format_indicator"`  // Tag 85: e.g. "CPV01"
+	Applications           []CPMApplication  `json:"applications"`              // Tag 61: Application templates
+	CommonData             *CPMApplication   `json:"common_data,omitempty"`     // Tag 64: Common data template
+	CommonDataTransparent  string            `json:"common_data_transparent"`   // Tag 62: Common data transparent template (hex)
+	UnresolvedData         map[string]string `json:"unresolved_data,omitempty"` // Other top-level tags (hex)
+}
+
+// CPMApplication represents an application template (tag 61) or the common data template (tag 64).
+type CPMApplication struct {
+	AID                     string            `json:"aid"`                       // Tag 4F: ADF name (hex)
+	Label                   string            `json:"label"`                     // Tag 50: Application label
+	Track2                  string            `json:"track2"`                    // Tag 57: Track 2 equivalent data
+	PAN                     string            `json:"pan"`                       // Tag 5A: Application PAN
+	CardholderName          string            `json:"cardholder_name"`           // Tag 5F20: Cardholder name
+	LanguagePreference      string            `json:"language_preference"`       // Tag 5F2D: Language preference
+	IssuerURL               string            `json:"issuer_url"`                // Tag 5F50: Issuer URL
+	ApplicationVersion      string            `json:"application_version"`       // Tag 9F08: Application version number (hex)
+	TokenRequestorID        string            `json:"token_requestor_id"`        // Tag 9F19: Token requestor ID (hex)
+	PaymentAccountReference string            `json:"payment_account_reference"` // Tag 9F24: Payment account reference
+	PANLast4                string            `json:"pan_last4"`                 // Tag 9F25: Last 4 digits of PAN
+	UnresolvedData          map[string]string `json:"unresolved_data,omitempty"` // Other tags, e.g. cryptogram data (hex)
+}
+
+// DecodeCPMQR decodes a base64-encoded EMV Consumer-Presented Mode QR payload.
+// The payload format indicator (tag 85) must be the first field and at least one
+// application template (tag 61) must be present. Like DecodeEMVQR it never panics
+// and rejects payloads longer than EMVMaxPayloadLength.
+func DecodeCPMQR(payload string) (*CPMData, error) {
+	payload = strings.TrimSpace(payload)
+	if payload == "" {
+		return nil, ErrEMVTooShort
+	}
+	if len(payload) > EMVMaxPayloadLength {
+		return nil, ErrEMVPayloadTooLong
+	}
+
+	data, err := decodeBase64(payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCPMInvalidEncoding, err)
+	}
+
+	fields, err := ParseBERTLV(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 || fields[0].Tag != cpmFormatIndicatorTag {
+		return nil, ErrEMVFormatIndicator
+	}
+
+	cpmData := &CPMData{PayloadFormatIndicator: string(fields[0].Value)}
+	for _, field := range fields[1:] {
+		switch field.Tag {
+		case "61":
+			cpmData.Applications = append(cpmData.Applications, newCPMApplication(field.Children))
+		case "64":
+			commonData := newCPMApplication(field.Children)
+			cpmData.CommonData = &commonData
+		case "62":
+			cpmData.CommonDataTransparent = strings.ToUpper(hex.EncodeToString(field.Value))
+		default:
+			setMapField(&cpmData.UnresolvedData, field.Tag, strings.ToUpper(hex.EncodeToString(field.Value)))
+		}
+	}
+
+	if len(cpmData.Applications) == 0 {
+		return nil, ErrCPMNoApplication
+	}
+	return cpmData, nil
+}
+
+// newCPMApplication maps the fields of an application or common data template.
+func newCPMApplication(fields []BERTLV) CPMApplication {
+	var app CPMApplication
+	for _, field := range fields {
+		hexValue := strings.ToUpper(hex.EncodeToString(field.Value))
+		switch field.Tag {
+		case "4F":
+			app.AID = hexValue
+		case "50":
+			app.Label = string(field.Value)
+		case "57":
+			app.Track2 = strings.TrimRight(hexValue, "F")
+		case "5A":
+			app.PAN = strings.TrimRight(hexValue, "F")
+		case "5F20":
+			app.CardholderName = strings.TrimSpace(string(field.Value))
+		case "5F2D":
+			app.LanguagePreference = string(field.Value)
+		case "5F50":
+			app.IssuerURL = string(field.Value)
+		case "9F08":
+			app.ApplicationVersion = hexValue
+		case "9F19":
+			app.TokenRequestorID = hexValue
+		case "9F24":
+			app.PaymentAccountReference = string(field.Value)
+		case "9F25":
+			app.PANLast4 = hexValue
+		default:
+			setMapField(&app.UnresolvedData, field.Tag, hexValue)
+		}
+	}
+	return app
+}
+
+// Masked returns a copy of the application with the PAN, track 2 data and
+// cardholder name masked for secure logging. Unresolved tags may embed the PAN
+// (e.g. issuer application data), so their hex values are masked as well.
+func (a CPMApplication) Masked() CPMApplication {
+	a.PAN = MaskSensitive(a.PAN)
+	a.Track2 = MaskSensitive(a.Track2)
+	a.CardholderName = MaskSensitive(a.CardholderName)
+	a.UnresolvedData = maskedValues(a.UnresolvedData)
+	return a
+}
+
+// Masked returns a copy of the CPM data with every application masked.
+// The common data transparent template and unresolved tags are raw hex that may
+// contain the PAN, so they are masked too.
+func (c *CPMData) Masked() *CPMData {
+	masked := *c
+	masked.Applications = make([]CPMApplication, len(c.Applications))
+	for i, app := range c.Applications {
+		masked.Applications[i] = app.Masked()
+	}
+	if c.CommonData != nil {
+		commonData := c.CommonData.Masked()
+		masked.CommonData = &commonData
+	}
+	masked.CommonDataTransparent = MaskSensitive(c.CommonDataTransparent)
+	masked.UnresolvedData = maskedValues(c.UnresolvedData)
+	return &masked
+}
+
+// maskedValues returns a copy of the map with every value masked by MaskSensitive.
+func maskedValues(values map[string]string) map[string]string {
+	if values == nil {
+		return nil
+	}
+	masked := make(map[string]string, len(values))
+	for key, value := range values {
+		masked[key] = MaskSensitive(value)
+	}
+	return masked
+}
@@ -0,0 +1,155 @@
+package xstr
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const cpmVisaQR = "hQVDUFYwMWFJTwegAAAAAxAQUARWSVNBVw5BEREREREREdJRIgEAD1oIQRERERERERFfIAlET0UvSk9ITiBfLQJlbp8lAhERnxAHBgEKA6AAAGQQnyYIESIzRFVmd4ifNgIAAQ=="
+
+func TestDecodeCPMQR(t *testing.T) {
+	cpmData, err := DecodeCPMQR(cpmVisaQR)
+	require.NoError(t, err)
+
+	assert.Equal(t, "CPV01", cpmData.PayloadFormatIndicator)
+	require.Len(t, cpmData.Applications, 1)
+	assert.Equal(t, CPMApplication{
+		AID:                "A0000000031010",
+		Label:              "VISA",
+		Track2:             "4111111111111111D2512201000",
+		PAN:                "4111111111111111",
+		CardholderName:     "DOE/JOHN",
+		LanguagePreference: "en",
+		PANLast4:           "1111",
+		UnresolvedData:     map[string]string{"9F10": "06010A03A00000"},
+	}, cpmData.Applications[0])
+	require.NotNil(t, cpmData.CommonData)
+	assert.Equal(t, map[string]string{"9F26": "1122334455667788", "9F36": "0001"}, cpmData.CommonData.UnresolvedData)
+	assert.Nil(t, cpmData.UnresolvedData)
+}
+
+func TestDecodeCPMQR_Variants(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		pan     string
+		wantErr error
+	}{
+		{
+			name:    "minimal application",
+			payload: "hQVDUFYwMWETTwegAAAABBAQWghUEzMAiQEENA==",
+			pan:     "5413330089010434",
+		},
+		{
+			name:    "unpadded base64 and surrounding whitespace",
+			payload: " hQVDUFYwMWETTwegAAAABBAQWghUEzMAiQEENA\n",
+			pan:     "5413330089010434",
+		},
+		{
+			name:    "empty",
+			payload: "",
+			wantErr: ErrEMVTooShort,
+		},
+		{
+			name:    "too long",
+			payload: strings.Repeat("A", EMVMaxPayloadLength+4),
+			wantErr: ErrEMVPayloadTooLong,
+		},
+		{
+			name:    "invalid base64",
+			payload: "not base64!",
+			wantErr: ErrCPMInvalidEncoding,
+		},
+		{
+			name:    "missing payload format indicator",
+			payload: "YQlPB6AAAAAEEBA=",
+			wantErr: ErrEMVFormatIndicator,
+		},
+		{
+			name:    "no application template",
+			payload: "hQVDUFYwMQ==",
+			wantErr: ErrCPMNoApplication,
+		},
+		{
+			name:    "value longer than payload",
+			payload: "hQVDUFYwMWEFYWI=",
+			wantErr: ErrEMVInvalidData,
+		},
+		{
+			name:    "truncated tag",
+			payload: "hQVDUFYwMZ8=",
+			wantErr: ErrEMVTrailingData,
+		},
+		{
+			name:    "indefinite length",
+			payload: "hQVDUFYwMWGA",
+			wantErr: ErrEMVInvalidLength,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpmData, err := DecodeCPMQR(tt.payload)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, cpmData)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, cpmData.Applications, 1)
+			assert.Equal(t, tt.pan, cpmData.Applications[0].PAN)
+		})
+	}
+}
+
+func TestCPMData_Masked(t *testing.T) {
+	cpmData, err := DecodeCPMQR(cpmVisaQR)
+	require.NoError(t, err)
+
+	masked := cpmData.Masked()
+	app := masked.Applications[0]
+	assert.Equal(t, "4111****1111", app.PAN)
+	assert.Equal(t, "4111****1000", app.Track2)
+	assert.Equal(t, "****HN", app.CardholderName)
+	assert.Equal(t, "1111", app.PANLast4)
+
+	assert.Equal(t, map[string]string{"9F10": "0601****0000"}, app.UnresolvedData)
+
+	// The original is left untouched
+	assert.Equal(t, "4111111111111111", cpmData.Applications[0].PAN)
+	assert.Equal(t, "06010A03A00000", cpmData.Applications[0].UnresolvedData["9F10"])
+}
+
+func TestCPMData_Masked_RawHex(t *testing.T) {
+	const pan = "4111111111111111"
+	cpmData := &CPMData{
+		Applications:          []CPMApplication{{PAN: pan, UnresolvedData: map[string]string{"9F10": "5A08" + pan + "00"}}},
+		CommonDataTransparent: "5A08" + pan,
+		UnresolvedData:        map[string]string{"63": "5A08" + pan + "9F2501"},
+	}
+
+	masked := cpmData.Masked()
+	data, err := json.Marshal(masked)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), pan)
+	assert.Equal(t, "5A08****1111", masked.CommonDataTransparent)
+	assert.Equal(t, "5A08"+pan, cpmData.CommonDataTransparent)
+}
+
+func FuzzDecodeCPMQR(f *testing.F) {
+	f.Add(cpmVisaQR)
+	f.Add("hQVDUFYwMWETTwegAAAABBAQWghUEzMAiQEENA==")
+	f.Add("hQVDUFYwMZ8=")
+	f.Add("hQVDUFYwMWGA")
+
+	f.Fuzz(func(t *testing.T, payload string) {
+		cpmData, err := DecodeCPMQR(payload)
+		if err == nil && len(cpmData.Applications) == 0 {
+			t.Fatalf("decoded %q without applications", payload)
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package xstr
+
+import (
+	"errors"
+	"fmt"
+)
+
+// emvCRCTag is the CRC tag and length header that precedes the 4-digit CRC value.
+const emvCRCTag = "6304"
+
+// Common EMV CRC errors.
+var (
+	ErrEMVCRCNotFound = errors.New("invalid EMV QR format: CRC tag not found at expected position")
+	ErrEMVCRCMismatch = errors.New("invalid CRC")
+)
+
+// ComputeEMVCRC computes the CRC-16 value for a payload that excludes the CRC field.
+// The "6304" CRC header is appended before calculation as required by the EMV specification.
+//
+// Examples:
+//   - ComputeEMVCRC("010201") -> "41C5"
+func ComputeEMVCRC(payload string) string {
+	return fmt.Sprintf("%04X", emvCRC16(payload))
+}
+
+// AppendEMVCRC appends the CRC field ("6304" + CRC value) to a payload without one.
+//
+// Examples:
+//   - AppendEMVCRC("010201") -> "010201630441C5"
+func AppendEMVCRC(payload string) string {
+	return payload + emvCRCTag + ComputeEMVCRC(payload)
+}
+
+// VerifyEMVCRC verifies that a QR string ends with a CRC field holding the correct checksum.
+// Returns ErrEMVCRCNotFound if the CRC field is not the last field,
+// or an error wrapping ErrEMVCRCMismatch if the checksum differs.
+func VerifyEMVCRC(qrString string) error {
+	if len(qrString) < 8 || qrString[len(qrString)-8:len(qrString)-4] != emvCRCTag {
+		return ErrEMVCRCNotFound
+	}
+
+	payload := qrString[:len(qrString)-8]
+	actualCRC := qrString[len(qrString)-4:]
+	if value, ok := parseCRCHex(actualCRC); ok && value == emvCRC16(payload) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: expected %s, got %s", ErrEMVCRCMismatch, ComputeEMVCRC(payload), actualCRC)
+}
+
+// RepairEMVCRC returns the QR string with a freshly computed CRC field.
+// A trailing CRC field (or bare "6304" header) is replaced; otherwise one is appended.
+// This is intended for building test fixtures and re-emitting edited payloads.
+func RepairEMVCRC(qrString string) string {
+	return AppendEMVCRC(stripEMVCRC(qrString))
+}
+
+// emvCRC16 computes the numeric CRC over a payload followed by the CRC header.
+func emvCRC16(payload string) uint16 {
+	return updateCRC16(updateCRC16(0xFFFF, payload), emvCRCTag)
+}
+
+// parseCRCHex parses a 4-digit uppercase hexadecimal CRC value.
+func parseCRCHex(s string) (uint16, bool) {
+	if len(s) != 4 {
+		return 0, false
+	}
+	var value uint16
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9':
+			value = value<<4 | uint16(c-'0')
+		case c >= 'A' && c <= 'F':
+			value = value<<4 | uint16(c-'A'+10)
+		default:
+			return 0, false
+		}
+	}
+	return value, true
+}
+
+// stripEMVCRC removes a trailing CRC field or bare CRC header from a QR string.
+func stripEMVCRC(qrString string) string {
+	switch {
+	case len(qrString) >= 8 && qrString[len(qrString)-8:len(qrString)-4] == emvCRCTag:
+		return qrString[:len(qrString)-8]
+	case len(qrString) >= 4 && qrString[len(qrString)-4:] == emvCRCTag:
+		return qrString[:len(qrString)-4]
+	default:
+		return qrString
+	}
+}
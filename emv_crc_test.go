@@ -0,0 +1,152 @@
+package xstr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeEMVCRC(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    string
+	}{
+		{
+			name:    "minimal payload",
+			payload: "010201",
+			want:    "41C5",
+		},
+		{
+			name:    "PromptPay mobile payload",
+			payload: "00020101021129370016A000000677010111011300668123456785802TH5303764540510.00",
+			want:    "4ABE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ComputeEMVCRC(tt.payload))
+			assert.Equal(t, tt.payload+"6304"+tt.want, AppendEMVCRC(tt.payload))
+		})
+	}
+}
+
+func TestVerifyEMVCRC(t *testing.T) {
+	tests := []struct {
+		name     string
+		qrString string
+		wantErr  error
+	}{
+		{
+			name:     "valid CRC",
+			qrString: "010201630441C5",
+		},
+		{
+			name:     "wrong CRC",
+			qrString: "010201630441C6",
+			wantErr:  ErrEMVCRCMismatch,
+		},
+		{
+			name:     "no CRC field",
+			qrString: "0102015802TH",
+			wantErr:  ErrEMVCRCNotFound,
+		},
+		{
+			name:     "too short",
+			qrString: "6304",
+			wantErr:  ErrEMVCRCNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyEMVCRC(tt.qrString)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRepairEMVCRC(t *testing.T) {
+	tests := []struct {
+		name     string
+		qrString string
+		want     string
+	}{
+		{"replaces wrong CRC", "010201630441C6", "010201630441C5"},
+		{"keeps valid CRC", "010201630441C5", "010201630441C5"},
+		{"completes bare CRC header", "0102016304", "010201630441C5"},
+		{"appends missing CRC", "010201", "010201630441C5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repaired := RepairEMVCRC(tt.qrString)
+			assert.Equal(t, tt.want, repaired)
+			assert.NoError(t, VerifyEMVCRC(repaired))
+		})
+	}
+}
+
+func TestDecodeEMVQR_CRCPolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		qrString      string
+		wantStrictErr error
+		wantLaxErr    error
+	}{
+		{
+			name:     "terminal valid CRC",
+			qrString: "0002010102116304AD0A",
+		},
+		{
+			name:          "missing CRC",
+			qrString:      "0002010102115802TH",
+			wantStrictErr: ErrEMVCRCNotFound,
+		},
+		{
+			name:          "non-terminal CRC",
+			qrString:      "0002010102116304E9FD5802TH",
+			wantStrictErr: ErrEMVCRCNotFound,
+			wantLaxErr:    ErrEMVCRCNotFound,
+		},
+		{
+			name:          "trailing bytes after CRC",
+			qrString:      "0002010102116304AD0A58",
+			wantStrictErr: ErrEMVCRCNotFound,
+			wantLaxErr:    ErrEMVCRCNotFound,
+		},
+		{
+			name:          "wrong CRC",
+			qrString:      "0002010102116304AD0B",
+			wantStrictErr: ErrEMVCRCMismatch,
+			wantLaxErr:    ErrEMVCRCMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strict, err := DecodeEMVQR(tt.qrString)
+			if tt.wantStrictErr != nil {
+				assert.ErrorIs(t, err, tt.wantStrictErr)
+				assert.Nil(t, strict)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, strict)
+			}
+
+			lax, err := DecodeEMVQRLenient(tt.qrString)
+			if tt.wantLaxErr != nil {
+				assert.ErrorIs(t, err, tt.wantLaxErr)
+				assert.Nil(t, lax)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, lax)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package xstr
+
+// iso4217Currency describes an ISO 4217 currency referenced by EMV tag 53.
+type iso4217Currency struct {
+	Alpha      string // Alphabetic code, e.g. "THB"
+	MinorUnits int    // Number of digits after the decimal separator
+	Country    string // ISO 3166-1 alpha-2 code of the issuing country, empty for multi-country currencies
+}
+
+// iso4217Currencies maps numeric ISO 4217 codes to currency details.
+// It covers the active ISO 4217 currencies, excluding funds, precious metals and
+// testing codes. Country is only set for currencies issued for a single country.
+var iso4217Currencies = map[string]iso4217Currency{
+	"008": {Alpha: "ALL", MinorUnits: 2, Country: "AL"},
+	"012": {Alpha: "DZD", MinorUnits: 2, Country: "DZ"},
+	"032": {Alpha: "ARS", MinorUnits: 2, Country: "AR"},
+	"036": {Alpha: "AUD", MinorUnits: 2, Country: "AU"},
+	"044": {Alpha: "BSD", MinorUnits: 2, Country: "BS"},
+	"048": {Alpha: "BHD", MinorUnits: 3, Country: "BH"},
+	"050": {Alpha: "BDT", MinorUnits: 2, Country: "BD"},
+	"051": {Alpha: "AMD", MinorUnits: 2, Country: "AM"},
+	"052": {Alpha: "BBD", MinorUnits: 2, Country: "BB"},
+	"060": {Alpha: "BMD", MinorUnits: 2, Country: "BM"},
+	"064": {Alpha: "BTN", MinorUnits: 2, Country: "BT"},
+	"068": {Alpha: "BOB", MinorUnits: 2, Country: "BO"},
+	"072": {Alpha: "BWP", MinorUnits: 2, Country: "BW"},
+	"084": {Alpha: "BZD", MinorUnits: 2, Country: "BZ"},
+	"090": {Alpha: "SBD", MinorUnits: 2, Country: "SB"},
+	"096": {Alpha: "BND", MinorUnits: 2, Country: "BN"},
+	"104": {Alpha: "MMK", MinorUnits: 2, Country: "MM"},
+	"108": {Alpha: "BIF", MinorUnits: 0, Country: "BI"},
+	"116": {Alpha: "KHR", MinorUnits: 2, Country: "KH"},
+	"124": {Alpha: "CAD", MinorUnits: 2, Country: "CA"},
+	"132": {Alpha: "CVE", MinorUnits: 2, Country: "CV"},
+	"136": {Alpha: "KYD", MinorUnits: 2, Country: "KY"},
+	"144": {Alpha: "LKR", MinorUnits: 2, Country: "LK"},
+	"152": {Alpha: "CLP", MinorUnits: 0, Country: "CL"},
+	"156": {Alpha: "CNY", MinorUnits: 2, Country: "CN"},
+	"170": {Alpha: "COP", MinorUnits: 2, Country: "CO"},
+	"174": {Alpha: "KMF", MinorUnits: 0, Country: "KM"},
+	"188": {Alpha: "CRC", MinorUnits: 2, Country: "CR"},
+	"192": {Alpha: "CUP", MinorUnits: 2, Country: "CU"},
+	"203": {Alpha: "CZK", MinorUnits: 2, Country: "CZ"},
+	"208": {Alpha: "DKK", MinorUnits: 2},
+	"214": {Alpha: "DOP", MinorUnits: 2, Country: "DO"},
+	"222": {Alpha: "SVC", MinorUnits: 2, Country: "SV"},
+	"230": {Alpha: "ETB", MinorUnits: 2, Country: "ET"},
+	"232": {Alpha: "ERN", MinorUnits: 2, Country: "ER"},
+	"238": {Alpha: "FKP", MinorUnits: 2, Country: "FK"},
+	"242": {Alpha: "FJD", MinorUnits: 2, Country: "FJ"},
+	"262": {Alpha: "DJF", MinorUnits: 0, Country: "DJ"},
+	"270": {Alpha: "GMD", MinorUnits: 2, Country: "GM"},
+	"292": {Alpha: "GIP", MinorUnits: 2, Country: "GI"},
+	"320": {Alpha: "GTQ", MinorUnits: 2, Country: "GT"},
+	"324": {Alpha: "GNF", MinorUnits: 0, Country: "GN"},
+	"328": {Alpha: "GYD", MinorUnits: 2, Country: "GY"},
+	"332": {Alpha: "HTG", MinorUnits: 2, Country: "HT"},
+	"340": {Alpha: "HNL", MinorUnits: 2, Country: "HN"},
+	"344": {Alpha: "HKD", MinorUnits: 2, Country: "HK"},
+	"348": {Alpha: "HUF", MinorUnits: 2, Country: "HU"},
+	"352": {Alpha: "ISK", MinorUnits: 0, Country: "IS"},
+	"356": {Alpha: "INR", MinorUnits: 2, Country: "IN"},
+	"360": {Alpha: "IDR", MinorUnits: 2, Country: "ID"},
+	"364": {Alpha: "IRR", MinorUnits: 2, Country: "IR"},
+	"368": {Alpha: "IQD", MinorUnits: 3, Country: "IQ"},
+	"376": {Alpha: "ILS", MinorUnits: 2},
+	"388": {Alpha: "JMD", MinorUnits: 2, Country: "JM"},
+	"392": {Alpha: "JPY", MinorUnits: 0, Country: "JP"},
+	"398": {Alpha: "KZT", MinorUnits: 2, Country: "KZ"},
+	"400": {Alpha: "JOD", MinorUnits: 3, Country: "JO"},
+	"404": {Alpha: "KES", MinorUnits: 2, Country: "KE"},
+	"408": {Alpha: "KPW", MinorUnits: 2, Country: "KP"},
+	"410": {Alpha: "KRW", MinorUnits: 0, Country: "KR"},
+	"414": {Alpha: "KWD", MinorUnits: 3, Country: "KW"},
+	"417": {Alpha: "KGS", MinorUnits: 2, Country: "KG"},
+	"418": {Alpha: "LAK", MinorUnits: 2, Country: "LA"},
+	"422": {Alpha: "LBP", MinorUnits: 2, Country: "LB"},
+	"426": {Alpha: "LSL", MinorUnits: 2, Country: "LS"},
+	"430": {Alpha: "LRD", MinorUnits: 2, Country: "LR"},
+	"434": {Alpha: "LYD", MinorUnits: 3, Country: "LY"},
+	"446": {Alpha: "MOP", MinorUnits: 2, Country: "MO"},
+	"454": {Alpha: "MWK", MinorUnits: 2, Country: "MW"},
+	"458": {Alpha: "MYR", MinorUnits: 2, Country: "MY"},
+	"462": {Alpha: "MVR", MinorUnits: 2, Country: "MV"},
+	"480": {Alpha: "MUR", MinorUnits: 2, Country: "MU"},
+	"484": {Alpha: "MXN", MinorUnits: 2, Country: "MX"},
+	"496": {Alpha: "MNT", MinorUnits: 2, Country: "MN"},
+	"498": {Alpha: "MDL", MinorUnits: 2, Country: "MD"},
+	"504": {Alpha: "MAD", MinorUnits: 2},
+	"512": {Alpha: "OMR", MinorUnits: 3, Country: "OM"},
+	"516": {Alpha: "NAD", MinorUnits: 2, Country: "NA"},
+	"524": {Alpha: "NPR", MinorUnits: 2, Country: "NP"},
+	"532": {Alpha: "ANG", MinorUnits: 2},
+	"533": {Alpha: "AWG", MinorUnits: 2, Country: "AW"},
+	"548": {Alpha: "VUV", MinorUnits: 0, Country: "VU"},
+	"554": {Alpha: "NZD", MinorUnits: 2},
+	"558": {Alpha: "NIO", MinorUnits: 2, Country: "NI"},
+	"566": {Alpha: "NGN", MinorUnits: 2, Country: "NG"},
+	"578": {Alpha: "NOK", MinorUnits: 2},
+	"586": {Alpha: "PKR", MinorUnits: 2, Country: "PK"},
+	"590": {Alpha: "PAB", MinorUnits: 2, Country: "PA"},
+	"598": {Alpha: "PGK", MinorUnits: 2, Country: "PG"},
+	"600": {Alpha: "PYG", MinorUnits: 0, Country: "PY"},
+	"604": {Alpha: "PEN", MinorUnits: 2, Country: "PE"},
+	"608": {Alpha: "PHP", MinorUnits: 2, Country: "PH"},
+	"634": {Alpha: "QAR", MinorUnits: 2, Country: "QA"},
+	"643": {Alpha: "RUB", MinorUnits: 2, Country: "RU"},
+	"646": {Alpha: "RWF", MinorUnits: 0, Country: "RW"},
+	"654": {Alpha: "SHP", MinorUnits: 2, Country: "SH"},
+	"682": {Alpha: "SAR", MinorUnits: 2, Country: "SA"},
+	"690": {Alpha: "SCR", MinorUnits: 2, Country: "SC"},
+	"702": {Alpha: "SGD", MinorUnits: 2, Country: "SG"},
+	"704": {Alpha: "VND", MinorUnits: 0, Country: "VN"},
+	"706": {Alpha: "SOS", MinorUnits: 2, Country: "SO"},
+	"710": {Alpha: "ZAR", MinorUnits: 2},
+	"728": {Alpha: "SSP", MinorUnits: 2, Country: "SS"},
+	"748": {Alpha: "SZL", MinorUnits: 2, Country: "SZ"},
+	"752": {Alpha: "SEK", MinorUnits: 2, Country: "SE"},
+	"756": {Alpha: "CHF", MinorUnits: 2},
+	"760": {Alpha: "SYP", MinorUnits: 2, Country: "SY"},
+	"764": {Alpha: "THB", MinorUnits: 2, Country: "TH"},
+	"776": {Alpha: "TOP", MinorUnits: 2, Country: "TO"},
+	"780": {Alpha: "TTD", MinorUnits: 2, Country: "TT"},
+	"784": {Alpha: "AED", MinorUnits: 2, Country: "AE"},
+	"788": {Alpha: "TND", MinorUnits: 3, Country: "TN"},
+	"800": {Alpha: "UGX", MinorUnits: 0, Country: "UG"},
+	"807": {Alpha: "MKD", MinorUnits: 2, Country: "MK"},
+	"818": {Alpha: "EGP", MinorUnits: 2, Country: "EG"},
+	"826": {Alpha: "GBP", MinorUnits: 2, Country: "GB"},
+	"834": {Alpha: "TZS", MinorUnits: 2, Country: "TZ"},
+	"840": {Alpha: "USD", MinorUnits: 2, Country: "US"},
+	"858": {Alpha: "UYU", MinorUnits: 2, Country: "UY"},
+	"860": {Alpha: "UZS", MinorUnits: 2, Country: "UZ"},
+	"882": {Alpha: "WST", MinorUnits: 2, Country: "WS"},
+	"886": {Alpha: "YER", MinorUnits: 2, Country: "YE"},
+	"901": {Alpha: "TWD", MinorUnits: 2, Country: "TW"},
+	"924": {Alpha: "ZWG", MinorUnits: 2, Country: "ZW"},
+	"925": {Alpha: "SLE", MinorUnits: 2, Country: "SL"},
+	"926": {Alpha: "VED", MinorUnits: 2},
+	"928": {Alpha: "VES", MinorUnits: 2, Country: "VE"},
+	"929": {Alpha: "MRU", MinorUnits: 2, Country: "MR"},
+	"930": {Alpha: "STN", MinorUnits: 2, Country: "ST"},
+	"933": {Alpha: "BYN", MinorUnits: 2, Country: "BY"},
+	"934": {Alpha: "TMT", MinorUnits: 2, Country: "TM"},
+	"936": {Alpha: "GHS", MinorUnits: 2, Country: "GH"},
+	"938": {Alpha: "SDG", MinorUnits: 2, Country: "SD"},
+	"941": {Alpha: "RSD", MinorUnits: 2, Country: "RS"},
+	"943": {Alpha: "MZN", MinorUnits: 2, Country: "MZ"},
+	"944": {Alpha: "AZN", MinorUnits: 2, Country: "AZ"},
+	"946": {Alpha: "RON", MinorUnits: 2, Country: "RO"},
+	"949": {Alpha: "TRY", MinorUnits: 2, Country: "TR"},
+	"950": {Alpha: "XAF", MinorUnits: 0},
+	"951": {Alpha: "XCD", MinorUnits: 2},
+	"952": {Alpha: "XOF", MinorUnits: 0},
+	"953": {Alpha: "XPF", MinorUnits: 0},
+	"967": {Alpha: "ZMW", MinorUnits: 2, Country: "ZM"},
+	"968": {Alpha: "SRD", MinorUnits: 2, Country: "SR"},
+	"969": {Alpha: "MGA", MinorUnits: 2, Country: "MG"},
+	"971": {Alpha: "AFN", MinorUnits: 2, Country: "AF"},
+	"972": {Alpha: "TJS", MinorUnits: 2, Country: "TJ"},
+	"973": {Alpha: "AOA", MinorUnits: 2, Country: "AO"},
+	"975": {Alpha: "BGN", MinorUnits: 2, Country: "BG"},
+	"976": {Alpha: "CDF", MinorUnits: 2, Country: "CD"},
+	"977": {Alpha: "BAM", MinorUnits: 2, Country: "BA"},
+	"978": {Alpha: "EUR", MinorUnits: 2},
+	"980": {Alpha: "UAH", MinorUnits: 2, Country: "UA"},
+	"981": {Alpha: "GEL", MinorUnits: 2, Country: "GE"},
+	"985": {Alpha: "PLN", MinorUnits: 2, Country: "PL"},
+	"986": {Alpha: "BRL", MinorUnits: 2, Country: "BR"},
+}
+
+// lookupCurrency returns the currency for a numeric ISO 4217 code.
+func lookupCurrency(numeric string) (iso4217Currency, bool) {
+	currency, ok := iso4217Currencies[numeric]
+	return currency, ok
+}
+
+// lookupCurrencyAlpha returns the currency details for an alphabetic ISO 4217 code.
+func lookupCurrencyAlpha(alpha string) (iso4217Currency, bool) {
+	for _, currency := range iso4217Currencies {
+		if currency.Alpha == alpha {
+			return currency, true
+		}
+	}
+	return iso4217Currency{}, false
+}
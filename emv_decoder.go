@@ -0,0 +1,258 @@
+package xstr
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Common EMV decoder errors.
+var (
+	ErrEMVTooShort         = errors.New("invalid EMV QR code: too short")
+	ErrEMVInvalidLength    = errors.New("invalid length")
+	ErrEMVInvalidData      = errors.New("invalid data length")
+	ErrEMVInvalidField     = errors.New("error mapping field")
+	ErrEMVPayloadTooLong   = errors.New("invalid EMV QR code: payload too long")
+	ErrEMVInvalidTag       = errors.New("invalid EMV QR code: invalid tag")
+	ErrEMVDuplicateTag     = errors.New("invalid EMV QR code: duplicate tag")
+	ErrEMVTrailingData     = errors.New("invalid EMV QR code: trailing data")
+	ErrEMVFormatIndicator  = errors.New("invalid EMV QR code: payload format indicator must be the first field")
+	ErrEMVSchemeNotAllowed = errors.New("invalid EMV QR code: no allowed payment scheme")
+)
+
+// Decoder decodes EMV QR code strings with configurable strictness.
+// A Decoder is immutable after construction and safe for concurrent use.
+type Decoder struct {
+	maxPayloadLength int
+	trimWhitespace   bool
+	stripZeroWidth   bool
+	lenientCRC       bool
+	strictTags       bool
+	requiredSchemes  []QRPaymentScheme
+	expiryCheck      bool
+	clock            Clock
+	expiryParsers    []ExpiryParser
+}
+
+// DecoderOption configures a Decoder.
+type DecoderOption func(*Decoder)
+
+// EMVMaxPayloadLength is the maximum QR payload length allowed by the EMV specification.
+// Decoders reject longer input by default to bound work on pathological payloads.
+const EMVMaxPayloadLength = 512
+
+// defaultDecoder backs DecodeEMVQR: strict CRC, lenient tag handling.
+var defaultDecoder = NewDecoder()
+
+// lenientDecoder backs DecodeEMVQRLenient: payloads may omit the CRC field.
+var lenientDecoder = NewDecoder(WithLenientCRC())
+
+// NewDecoder creates a Decoder configured with the given options.
+// Without options it behaves exactly like DecodeEMVQR.
+//
+// Examples:
+//   - NewDecoder(WithTrimWhitespace(), WithStripZeroWidth())
+//   - NewDecoder(WithStrictTags(), WithRequiredSchemes(QRSchemePromptPay))
+func NewDecoder(opts ...DecoderOption) *Decoder {
+	d := &Decoder{
+		maxPayloadLength: EMVMaxPayloadLength,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// WithMaxPayloadLength rejects payloads longer than n characters (after cleaning).
+// The default is EMVMaxPayloadLength; a value of 0 disables the limit.
+func WithMaxPayloadLength(n int) DecoderOption {
+	return func(d *Decoder) {
+		d.maxPayloadLength = n
+	}
+}
+
+// WithTrimWhitespace removes leading and trailing whitespace and newlines added by scanners.
+func WithTrimWhitespace() DecoderOption {
+	return func(d *Decoder) {
+		d.trimWhitespace = true
+	}
+}
+
+// WithStripZeroWidth removes zero-width characters anywhere in the payload,
+// using the same character set as RemoveDuplicateSpaces.
+func WithStripZeroWidth() DecoderOption {
+	return func(d *Decoder) {
+		d.stripZeroWidth = true
+	}
+}
+
+// WithLenientCRC accepts payloads without a CRC field.
+// A CRC field that is present must still be the last field and hold the correct checksum.
+func WithLenientCRC() DecoderOption {
+	return func(d *Decoder) {
+		d.lenientCRC = true
+	}
+}
+
+// WithStrictTags rejects non-numeric tags, duplicate tags, trailing data, unreserved
+// templates (tags 80-99) that are not TLV and payloads that do not start with the
+// payload format indicator "000201". Without it, such templates keep only their RawValue.
+func WithStrictTags() DecoderOption {
+	return func(d *Decoder) {
+		d.strictTags = true
+	}
+}
+
+// WithRequiredSchemes requires at least one merchant account with one of the given schemes.
+func WithRequiredSchemes(schemes ...QRPaymentScheme) DecoderOption {
+	return func(d *Decoder) {
+		d.requiredSchemes = slices.Clone(schemes)
+	}
+}
+
+// WithExpiryCheck rejects QRs whose embedded validity window does not contain the time
+// reported by clock (time.Now when nil) with ErrEMVQRExpired or ErrEMVQRNotYetValid.
+// Malformed timestamps are rejected with ErrEMVInvalidTimestamp. DefaultExpiryParsers
+// are used when no parsers are given.
+func WithExpiryCheck(clock Clock, parsers ...ExpiryParser) DecoderOption {
+	return func(d *Decoder) {
+		d.expiryCheck = true
+		d.clock = clock
+		d.expiryParsers = slices.Clone(parsers)
+	}
+}
+
+// Decode decodes EMV QR code string and returns structured data.
+// Decode never panics: malformed input of any kind is reported as an error.
+func (d *Decoder) Decode(qrString string) (*EMVData, error) {
+	qrString = d.clean(qrString)
+
+	if len(qrString) < 4 {
+		return nil, ErrEMVTooShort
+	}
+	if d.maxPayloadLength > 0 && len(qrString) > d.maxPayloadLength {
+		return nil, ErrEMVPayloadTooLong
+	}
+	if d.strictTags && !strings.HasPrefix(qrString, "000201") {
+		return nil, ErrEMVFormatIndicator
+	}
+
+	emvData := &EMVData{}
+
+	// Parse TLV data sequentially from QR string
+	lastTag := ""
+	hasCRC := false
+	var seenTags [100]bool
+	for field, err := range EMVTLVSeq(qrString) {
+		if errors.Is(err, ErrEMVTrailingData) && !d.strictTags {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if d.strictTags {
+			if !isDigits(field.Tag) {
+				return nil, fmt.Errorf("%w: %s", ErrEMVInvalidTag, field.Tag)
+			}
+			index := int(field.Tag[0]-'0')*10 + int(field.Tag[1]-'0')
+			if seenTags[index] {
+				return nil, fmt.Errorf("%w: %s", ErrEMVDuplicateTag, field.Tag)
+			}
+			seenTags[index] = true
+		}
+
+		// Map to appropriate field
+		err = mapEMVField(emvData, field.Tag, field.Value)
+		if errors.Is(err, errEMVMalformedTemplate) && !d.strictTags {
+			err = nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w %s: %v", ErrEMVInvalidField, field.Tag, err)
+		}
+
+		lastTag = field.Tag
+		if field.Tag == "63" {
+			hasCRC = true
+		}
+	}
+
+	// Validate CRC checksum to ensure data integrity
+	// EMV standard requires CRC-16 validation on complete QR data,
+	// with the CRC as the last field so it covers every other field
+	if hasCRC || !d.lenientCRC {
+		if lastTag != "63" {
+			return nil, ErrEMVCRCNotFound
+		}
+		if err := VerifyEMVCRC(qrString); err != nil {
+			return nil, err
+		}
+	}
+
+	emvData.allocateMaps()
+
+	// Amounts are validated once the currency (tag 53) is known, wherever it appears
+	if tag, err := emvData.checkAmounts(); err != nil {
+		return nil, fmt.Errorf("%w %s: %w", ErrEMVInvalidField, tag, err)
+	}
+
+	if len(d.requiredSchemes) > 0 && !hasAnyScheme(emvData, d.requiredSchemes) {
+		return nil, ErrEMVSchemeNotAllowed
+	}
+
+	if d.expiryCheck {
+		if err := d.checkExpiry(emvData); err != nil {
+			return nil, err
+		}
+	}
+
+	return emvData, nil
+}
+
+// checkExpiry verifies the QR validity window against the decoder clock.
+func (d *Decoder) checkExpiry(emvData *EMVData) error {
+	validity, err := emvData.Validity(d.expiryParsers...)
+	if err != nil || validity == nil {
+		return err
+	}
+
+	now := time.Now()
+	if d.clock != nil {
+		now = d.clock()
+	}
+	switch {
+	case validity.ExpiresAt != nil && !now.Before(*validity.ExpiresAt):
+		return fmt.Errorf("%w at %s", ErrEMVQRExpired, validity.ExpiresAt.Format(time.RFC3339))
+	case !validity.ValidAt(now):
+		return fmt.Errorf("%w until %s", ErrEMVQRNotYetValid, validity.CreatedAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// clean applies the configured input normalization before parsing.
+func (d *Decoder) clean(qrString string) string {
+	if d.stripZeroWidth {
+		qrString = strings.Map(func(r rune) rune {
+			if zeroWidthChars[r] {
+				return -1
+			}
+			return r
+		}, qrString)
+	}
+	if d.trimWhitespace {
+		qrString = strings.TrimSpace(qrString)
+	}
+	return qrString
+}
+
+// hasAnyScheme reports whether any merchant account uses one of the given schemes.
+func hasAnyScheme(emvData *EMVData, schemes []QRPaymentScheme) bool {
+	for _, account := range emvData.MerchantAccountInfo {
+		if slices.Contains(schemes, account.PaymentScheme) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,158 @@
+package xstr
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoder_Decode(t *testing.T) {
+	const promptPayQR = "00020101021129370016A000000677010111011300668123456785802TH5303764540510.0063044ABE"
+
+	tests := []struct {
+		name     string
+		opts     []DecoderOption
+		qrString string
+		wantErr  error
+		errMsg   string
+	}{
+		{
+			name:     "default decoder",
+			qrString: promptPayQR,
+		},
+		{
+			name:     "trailing newline rejected by default",
+			qrString: promptPayQR + "\r\n",
+			errMsg:   "CRC tag not found",
+		},
+		{
+			name:     "trailing newline trimmed",
+			opts:     []DecoderOption{WithTrimWhitespace()},
+			qrString: " " + promptPayQR + "\r\n",
+		},
+		{
+			name:     "zero-width characters stripped",
+			opts:     []DecoderOption{WithStripZeroWidth()},
+			qrString: "\uFEFF" + promptPayQR[:20] + "\u200B" + promptPayQR[20:],
+		},
+		{
+			name:     "payload within max length",
+			opts:     []DecoderOption{WithMaxPayloadLength(len(promptPayQR))},
+			qrString: promptPayQR,
+		},
+		{
+			name:     "payload exceeds max length",
+			opts:     []DecoderOption{WithMaxPayloadLength(64)},
+			qrString: promptPayQR,
+			wantErr:  ErrEMVPayloadTooLong,
+		},
+		{
+			name:     "default rejects payload over EMV limit",
+			qrString: RepairEMVCRC("000201" + "9999" + strings.Repeat("0", 99) + "9899" + strings.Repeat("0", 99) + "9799" + strings.Repeat("0", 99) + "9699" + strings.Repeat("0", 99) + "9599" + strings.Repeat("0", 99)),
+			wantErr:  ErrEMVPayloadTooLong,
+		},
+		{
+			name:     "limit disabled",
+			opts:     []DecoderOption{WithMaxPayloadLength(0)},
+			qrString: RepairEMVCRC("000201" + "9999" + strings.Repeat("0", 99) + "9899" + strings.Repeat("0", 99) + "9799" + strings.Repeat("0", 99) + "9699" + strings.Repeat("0", 99) + "9599" + strings.Repeat("0", 99)),
+		},
+		{
+			name:     "required scheme present",
+			opts:     []DecoderOption{WithRequiredSchemes(QRSchemeQRIS, QRSchemePromptPay)},
+			qrString: promptPayQR,
+		},
+		{
+			name:     "required scheme missing",
+			opts:     []DecoderOption{WithRequiredSchemes(QRSchemeQRIS)},
+			qrString: promptPayQR,
+			wantErr:  ErrEMVSchemeNotAllowed,
+		},
+		{
+			name:     "lenient CRC accepts missing CRC",
+			opts:     []DecoderOption{WithLenientCRC()},
+			qrString: "0002010102115802TH",
+		},
+		{
+			name:     "strict tags accept well-formed payload",
+			opts:     []DecoderOption{WithStrictTags()},
+			qrString: promptPayQR,
+		},
+		{
+			name:     "strict tags require payload format indicator first",
+			opts:     []DecoderOption{WithStrictTags()},
+			qrString: "010201630441C5",
+			wantErr:  ErrEMVFormatIndicator,
+		},
+		{
+			name:     "strict tags reject duplicate tag",
+			opts:     []DecoderOption{WithStrictTags(), WithLenientCRC()},
+			qrString: "0002010102115802TH5802TH",
+			wantErr:  ErrEMVDuplicateTag,
+		},
+		{
+			name:     "strict tags reject non-numeric tag",
+			opts:     []DecoderOption{WithStrictTags(), WithLenientCRC()},
+			qrString: "000201AB02TH",
+			wantErr:  ErrEMVInvalidTag,
+		},
+		{
+			name:     "strict tags reject trailing data",
+			opts:     []DecoderOption{WithStrictTags(), WithLenientCRC()},
+			qrString: "0002010102115",
+			wantErr:  ErrEMVTrailingData,
+		},
+		{
+			name:     "lenient tags ignore trailing data",
+			opts:     []DecoderOption{WithLenientCRC()},
+			qrString: "0002010102115",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewDecoder(tt.opts...).Decode(tt.qrString)
+
+			switch {
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, result)
+			case tt.errMsg != "":
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				assert.Nil(t, result)
+			default:
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+			}
+		})
+	}
+}
+
+func TestDecoder_ConcurrentUse(t *testing.T) {
+	decoder := NewDecoder(WithTrimWhitespace(), WithRequiredSchemes(QRSchemePromptPay))
+	qrString := "00020101021129370016A000000677010111011300668123456785802TH5303764540510.0063044ABE\n"
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := decoder.Decode(qrString)
+			assert.NoError(t, err)
+			assert.NotNil(t, result)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestWithRequiredSchemes_CopiesInput(t *testing.T) {
+	schemes := []QRPaymentScheme{QRSchemePromptPay}
+	decoder := NewDecoder(WithRequiredSchemes(schemes...))
+	schemes[0] = QRSchemeQRIS
+
+	_, err := decoder.Decode("00020101021129370016A000000677010111011300668123456785802TH5303764540510.0063044ABE")
+	assert.NoError(t, err)
+}
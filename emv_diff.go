@@ -0,0 +1,221 @@
+package xstr
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// EMVChangeKind represents how a field differs between two payloads.
+type EMVChangeKind string
+
+// EMV change kind constants
+const (
+	EMVChangeAdded   EMVChangeKind = "added"   // Field present only in the new payload
+	EMVChangeRemoved EMVChangeKind = "removed" // Field present only in the old payload
+	EMVChangeChanged EMVChangeKind = "changed" // Field present in both with different values
+)
+
+// EMVFieldChange describes a single field difference.
+type EMVFieldChange struct {
+	Kind       EMVChangeKind `json:"kind"`
+	Tag        string        `json:"tag"`
+	Occurrence int           `json:"occurrence,omitempty"` // 0-based occurrence of a repeated tag
+	SubTag     string        `json:"sub_tag,omitempty"`    // Set for sub-fields of templates
+	Old        string        `json:"old,omitempty"`
+	New        string        `json:"new,omitempty"`
+}
+
+// Path returns the field path, e.g. "54", "29.01" or "29[1]" for the second tag 29.
+func (c EMVFieldChange) Path() string {
+	path := c.Tag
+	if c.Occurrence > 0 {
+		path += fmt.Sprintf("[%d]", c.Occurrence)
+	}
+	if c.SubTag != "" {
+		path += "." + c.SubTag
+	}
+	return path
+}
+
+// EMVDiff is the semantic difference between two EMV payloads.
+type EMVDiff struct {
+	Changes     []EMVFieldChange `json:"changes"`
+	OldCRCValid bool             `json:"old_crc_valid"`
+	NewCRCValid bool             `json:"new_crc_valid"`
+}
+
+// EMVDiffOption configures DiffEMVQR.
+type EMVDiffOption func(*emvDiffConfig)
+
+// emvDiffConfig holds the DiffEMVQR options.
+type emvDiffConfig struct {
+	ignoredTags     map[string]bool
+	ignoreStaticPOI bool
+}
+
+// WithIgnoreAmount ignores the transaction amount (tag 54) and a point of initiation
+// method (tag 01) switched between static "11" and dynamic "12", so a static QR and the
+// same QR converted with ToDynamicEMVQR compare equal.
+func WithIgnoreAmount() EMVDiffOption {
+	return func(c *emvDiffConfig) {
+		c.ignoredTags["54"] = true
+		c.ignoreStaticPOI = true
+	}
+}
+
+// DiffEMVQR compares two EMV payloads field by field, descending into merchant
+// account templates (26-51), additional data (62) and other templates. Repeated tags
+// are compared by occurrence, so an account added next to an existing one with the
+// same tag is reported as added rather than as a change of the existing account.
+// The CRC field is never compared; its validity is reported on the result instead.
+// Payloads are parsed with ParseEMVTLV, so a broken CRC does not prevent comparison.
+func DiffEMVQR(oldQR, newQR string, opts ...EMVDiffOption) (*EMVDiff, error) {
+	config := &emvDiffConfig{
+		ignoredTags: map[string]bool{"63": true},
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	oldFields, err := ParseEMVTLV(oldQR)
+	if err != nil {
+		return nil, fmt.Errorf("old payload: %w", err)
+	}
+	newFields, err := ParseEMVTLV(newQR)
+	if err != nil {
+		return nil, fmt.Errorf("new payload: %w", err)
+	}
+
+	diff := &EMVDiff{
+		OldCRCValid: VerifyEMVCRC(oldQR) == nil,
+		NewCRCValid: VerifyEMVCRC(newQR) == nil,
+	}
+
+	oldValues := tlvValues(oldFields)
+	newValues := tlvValues(newFields)
+	for _, tag := range unionKeys(oldValues, newValues) {
+		if config.ignoredTags[tag] {
+			continue
+		}
+		oldOccurrences, newOccurrences := oldValues[tag], newValues[tag]
+		if tag == "01" && config.ignoreStaticPOI && len(oldOccurrences) == 1 && len(newOccurrences) == 1 &&
+			isStaticDynamicPOI(oldOccurrences[0]) && isStaticDynamicPOI(newOccurrences[0]) {
+			continue
+		}
+		for occurrence := range max(len(oldOccurrences), len(newOccurrences)) {
+			diff.Changes = append(diff.Changes, diffEMVField(tag, occurrence, oldOccurrences, newOccurrences)...)
+		}
+	}
+
+	return diff, nil
+}
+
+// Equal reports whether the payloads have no compared differences.
+func (d *EMVDiff) Equal() bool {
+	return len(d.Changes) == 0
+}
+
+// String renders the differences one per line:
+// "+" for added, "-" for removed and "~" for changed fields.
+func (d *EMVDiff) String() string {
+	var b strings.Builder
+	for _, change := range d.Changes {
+		switch change.Kind {
+		case EMVChangeAdded:
+			fmt.Fprintf(&b, "+ %s: %q\n", change.Path(), change.New)
+		case EMVChangeRemoved:
+			fmt.Fprintf(&b, "- %s: %q\n", change.Path(), change.Old)
+		default:
+			fmt.Fprintf(&b, "~ %s: %q -> %q\n", change.Path(), change.Old, change.New)
+		}
+	}
+	if !d.OldCRCValid {
+		b.WriteString("! old payload CRC is invalid\n")
+	}
+	if !d.NewCRCValid {
+		b.WriteString("! new payload CRC is invalid\n")
+	}
+	if b.Len() == 0 {
+		return "no differences\n"
+	}
+	return b.String()
+}
+
+// diffEMVField compares one occurrence of a top-level tag, descending into templates
+// when both sides parse as sub-fields.
+func diffEMVField(tag string, occurrence int, oldValues, newValues []string) []EMVFieldChange {
+	inOld := occurrence < len(oldValues)
+	inNew := occurrence < len(newValues)
+
+	switch {
+	case !inOld:
+		return []EMVFieldChange{{Kind: EMVChangeAdded, Tag: tag, Occurrence: occurrence, New: newValues[occurrence]}}
+	case !inNew:
+		return []EMVFieldChange{{Kind: EMVChangeRemoved, Tag: tag, Occurrence: occurrence, Old: oldValues[occurrence]}}
+	}
+	oldValue, newValue := oldValues[occurrence], newValues[occurrence]
+	if oldValue == newValue {
+		return nil
+	}
+
+	if isEMVTemplateTag(tag) {
+		oldSubFields, oldErr := parseSubFields(oldValue)
+		newSubFields, newErr := parseSubFields(newValue)
+		if oldErr == nil && newErr == nil {
+			var changes []EMVFieldChange
+			for _, subTag := range unionKeys(oldSubFields, newSubFields) {
+				oldSub, inOldSub := oldSubFields[subTag]
+				newSub, inNewSub := newSubFields[subTag]
+				switch {
+				case !inOldSub:
+					changes = append(changes, EMVFieldChange{Kind: EMVChangeAdded, Tag: tag, Occurrence: occurrence, SubTag: subTag, New: newSub})
+				case !inNewSub:
+					changes = append(changes, EMVFieldChange{Kind: EMVChangeRemoved, Tag: tag, Occurrence: occurrence, SubTag: subTag, Old: oldSub})
+				case oldSub != newSub:
+					changes = append(changes, EMVFieldChange{Kind: EMVChangeChanged, Tag: tag, Occurrence: occurrence, SubTag: subTag, Old: oldSub, New: newSub})
+				}
+			}
+			// Same sub-fields in a different order are not a semantic change
+			return changes
+		}
+	}
+
+	return []EMVFieldChange{{Kind: EMVChangeChanged, Tag: tag, Occurrence: occurrence, Old: oldValue, New: newValue}}
+}
+
+// isStaticDynamicPOI reports whether a point of initiation method is static "11" or dynamic "12".
+func isStaticDynamicPOI(value string) bool {
+	return value == "11" || value == "12"
+}
+
+// isEMVTemplateTag reports whether a top-level tag holds TLV sub-fields:
+// merchant account templates (26-51), additional data (62),
+// merchant information language template (64) and unreserved templates (80-99).
+func isEMVTemplateTag(tag string) bool {
+	return (tag >= "26" && tag <= "51") || tag == "62" || tag == "64" || (tag >= "80" && tag <= "99")
+}
+
+// tlvValues indexes fields by tag, keeping every value of repeated tags in payload order.
+func tlvValues(fields []EMVDataValue) map[string][]string {
+	values := make(map[string][]string, len(fields))
+	for _, field := range fields {
+		values[field.Tag] = append(values[field.Tag], field.Value)
+	}
+	return values
+}
+
+// unionKeys returns the sorted union of the keys of a and b.
+func unionKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, exists := a[key]; !exists {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
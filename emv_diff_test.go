@@ -0,0 +1,179 @@
+package xstr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	diffPromptPayQR        = "00020101021129370016A000000677010111011300668123456785802TH5303764540510.0063044ABE"
+	diffPromptPayStaticQR  = "00020101021129370016A000000677010111011300668123456785802TH530376463045D82"
+	diffPromptPaySwappedQR = "00020101021129370016A000000677010111011300669999999995802TH5303764540510.00630448C7"
+	diffBillPaymentQR      = "00020101021230590016A000000677010112011501075370008820502061234560306ABCDEF530376454031005802TH62210105INV010708TERM00016304C43B"
+	diffBillPaymentRefQR   = "00020101021230590016A000000677010112011501075370008820502061234560306ABCDEF530376454031005802TH62210105INV020708TERM00016304F51D"
+)
+
+func TestDiffEMVQR_IgnoreAmountDynamicConversion(t *testing.T) {
+	dynamicQR, err := ToDynamicEMVQR(diffPromptPayStaticQR, "10.00")
+	require.NoError(t, err)
+
+	diff, err := DiffEMVQR(diffPromptPayStaticQR, dynamicQR, WithIgnoreAmount())
+	require.NoError(t, err)
+	assert.True(t, diff.Equal(), diff.String())
+
+	diff, err = DiffEMVQR(diffPromptPayStaticQR, dynamicQR)
+	require.NoError(t, err)
+	assert.Equal(t, []EMVFieldChange{
+		{Kind: EMVChangeChanged, Tag: "01", Old: "11", New: "12"},
+		{Kind: EMVChangeAdded, Tag: "54", New: "10.00"},
+	}, diff.Changes)
+
+	// Other point of initiation values are still compared
+	diff, err = DiffEMVQR("000201010211", "000201010213", WithIgnoreAmount())
+	require.NoError(t, err)
+	assert.Equal(t, []EMVFieldChange{{Kind: EMVChangeChanged, Tag: "01", Old: "11", New: "13"}}, diff.Changes)
+}
+
+func TestDiffEMVQR(t *testing.T) {
+	tests := []struct {
+		name     string
+		oldQR    string
+		newQR    string
+		opts     []EMVDiffOption
+		expected []EMVFieldChange
+	}{
+		{
+			name:  "identical payloads",
+			oldQR: diffPromptPayQR,
+			newQR: diffPromptPayQR,
+		},
+		{
+			name:  "amount removed",
+			oldQR: diffPromptPayQR,
+			newQR: diffPromptPayStaticQR,
+			expected: []EMVFieldChange{
+				{Kind: EMVChangeRemoved, Tag: "54", Old: "10.00"},
+			},
+		},
+		{
+			name:  "amount ignored",
+			oldQR: diffPromptPayQR,
+			newQR: diffPromptPayStaticQR,
+			opts:  []EMVDiffOption{WithIgnoreAmount()},
+		},
+		{
+			name:  "merchant account sub-field changed",
+			oldQR: diffPromptPayQR,
+			newQR: diffPromptPaySwappedQR,
+			expected: []EMVFieldChange{
+				{Kind: EMVChangeChanged, Tag: "29", SubTag: "01", Old: "0066812345678", New: "0066999999999"},
+			},
+		},
+		{
+			name:  "additional data sub-field changed",
+			oldQR: diffBillPaymentQR,
+			newQR: diffBillPaymentRefQR,
+			expected: []EMVFieldChange{
+				{Kind: EMVChangeChanged, Tag: "62", SubTag: "01", Old: "INV01", New: "INV02"},
+			},
+		},
+		{
+			name:  "different schemes",
+			oldQR: diffPromptPayStaticQR,
+			newQR: diffBillPaymentQR,
+			expected: []EMVFieldChange{
+				{Kind: EMVChangeChanged, Tag: "01", Old: "11", New: "12"},
+				{Kind: EMVChangeRemoved, Tag: "29", Old: "0016A00000067701011101130066812345678"},
+				{Kind: EMVChangeAdded, Tag: "30", New: "0016A000000677010112011501075370008820502061234560306ABCDEF"},
+				{Kind: EMVChangeAdded, Tag: "54", New: "100"},
+				{Kind: EMVChangeAdded, Tag: "62", New: "0105INV010708TERM0001"},
+			},
+		},
+		{
+			name:  "sub-field order is not a change",
+			oldQR: "00020101021126120002AB0102CD6304BC9B",
+			newQR: "00020101021126120102CD0002AB6304489F",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff, err := DiffEMVQR(tt.oldQR, tt.newQR, tt.opts...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, diff.Changes)
+			assert.Equal(t, len(tt.expected) == 0, diff.Equal())
+		})
+	}
+}
+
+func TestDiffEMVQR_RepeatedTag(t *testing.T) {
+	// A second tag 29 account stuck next to the genuine one
+	tamperedQR := AppendEMVCRC("00020101021129370016A0000006770101110113006681234567829370016A000000677010111011300668999999995802TH5303764540510.00")
+
+	diff, err := DiffEMVQR(diffPromptPayQR, tamperedQR)
+	require.NoError(t, err)
+	assert.Equal(t, []EMVFieldChange{
+		{Kind: EMVChangeAdded, Tag: "29", Occurrence: 1, New: "0016A00000067701011101130066899999999"},
+	}, diff.Changes)
+	assert.Equal(t, "+ 29[1]: \"0016A00000067701011101130066899999999\"\n", diff.String())
+
+	diff, err = DiffEMVQR(tamperedQR, diffPromptPayQR)
+	require.NoError(t, err)
+	assert.Equal(t, []EMVFieldChange{
+		{Kind: EMVChangeRemoved, Tag: "29", Occurrence: 1, Old: "0016A00000067701011101130066899999999"},
+	}, diff.Changes)
+}
+
+func TestDiffEMVQR_CRC(t *testing.T) {
+	broken := diffPromptPayQR[:len(diffPromptPayQR)-4] + "0000"
+
+	diff, err := DiffEMVQR(diffPromptPayQR, broken)
+	require.NoError(t, err)
+	assert.True(t, diff.Equal())
+	assert.True(t, diff.OldCRCValid)
+	assert.False(t, diff.NewCRCValid)
+}
+
+func TestDiffEMVQR_Errors(t *testing.T) {
+	_, err := DiffEMVQR("00XX", diffPromptPayQR)
+	assert.ErrorIs(t, err, ErrEMVInvalidLength)
+	assert.Contains(t, err.Error(), "old payload")
+
+	_, err = DiffEMVQR(diffPromptPayQR, "000501")
+	assert.ErrorIs(t, err, ErrEMVInvalidData)
+	assert.Contains(t, err.Error(), "new payload")
+}
+
+func TestEMVDiff_String(t *testing.T) {
+	tests := []struct {
+		name     string
+		oldQR    string
+		newQR    string
+		expected string
+	}{
+		{
+			name:     "no differences",
+			oldQR:    diffPromptPayQR,
+			newQR:    diffPromptPayQR,
+			expected: "no differences\n",
+		},
+		{
+			name:  "changes and invalid CRC",
+			oldQR: diffPromptPayQR,
+			newQR: "00020101021129370016A000000677010111011300669999999995802TH53037646304FFFF",
+			expected: "~ 29.01: \"0066812345678\" -> \"0066999999999\"\n" +
+				"- 54: \"10.00\"\n" +
+				"! new payload CRC is invalid\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff, err := DiffEMVQR(tt.oldQR, tt.newQR)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, diff.String())
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package xstr
+
+import (
+	"fmt"
+)
+
+// AmountOption configures SetAmount and ToDynamicEMVQR.
+type AmountOption func(*amountConfig)
+
+// amountConfig holds the tag 62 sub-fields set together with the amount.
+type amountConfig struct {
+	additionalData map[string]string
+}
+
+// WithAdditionalDataField sets a tag 62 sub-field, e.g. "01" for the bill number.
+func WithAdditionalDataField(subTag, value string) AmountOption {
+	return func(c *amountConfig) {
+		c.additionalData[subTag] = value
+	}
+}
+
+// WithBillNumber sets the bill number (tag 62 sub-field 01).
+func WithBillNumber(billNumber string) AmountOption {
+	return WithAdditionalDataField("01", billNumber)
+}
+
+// WithReferenceLabel sets the reference label (tag 62 sub-field 05).
+func WithReferenceLabel(reference string) AmountOption {
+	return WithAdditionalDataField("05", reference)
+}
+
+// WithTerminalLabel sets the terminal label (tag 62 sub-field 07).
+func WithTerminalLabel(terminal string) AmountOption {
+	return WithAdditionalDataField("07", terminal)
+}
+
+// SetAmount turns e into a dynamic QR: it sets the transaction amount (tag 54),
+// switches the point of initiation method to 12 and applies the tag 62 options.
+// The amount must fit the minor units of the transaction currency (tag 53),
+// e.g. "100.50" for THB but only "100" for JPY.
+func (e *EMVData) SetAmount(amount string, opts ...AmountOption) error {
+	currency, ok := lookupCurrency(e.TransactionCurrency)
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrEMVUnknownCurrency, e.TransactionCurrency)
+	}
+	if err := checkEMVAmount(amount, currency.MinorUnits); err != nil {
+		return fmt.Errorf("%w for %s", err, currency.Alpha)
+	}
+
+	config := &amountConfig{additionalData: make(map[string]string)}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	e.TransactionAmount = amount
+	e.PointOfInitiationMethod = "12"
+	e.POIMethodType = POITypeDynamic
+	for subTag, value := range config.additionalData {
+		setMapField(&e.AdditionalData, subTag, value)
+	}
+	return nil
+}
+
+// SetAmountMinorUnits is SetAmount with an amount in minor units of the transaction currency,
+// written in canonical form, e.g. 10050 -> "100.50" for THB.
+func (e *EMVData) SetAmountMinorUnits(minor int64, opts ...AmountOption) error {
+	amount, err := FormatEMVAmount(minor, e.TransactionCurrency)
+	if err != nil {
+		return err
+	}
+	return e.SetAmount(amount, opts...)
+}
+
+// ClearAmount turns e into a static QR: it removes the transaction amount (tag 54)
+// and switches the point of initiation method to 11. Tag 62 sub-fields are kept.
+func (e *EMVData) ClearAmount() {
+	e.TransactionAmount = ""
+	e.PointOfInitiationMethod = "11"
+	e.POIMethodType = POITypeStatic
+}
+
+// ToDynamicEMVQR converts a QR payload into a dynamic one carrying the amount,
+// re-emitted with a fresh CRC. See SetAmount for the amount rules.
+//
+// Examples:
+//   - ToDynamicEMVQR(staticPromptPayQR, "10.00", WithBillNumber("INV01"))
+func ToDynamicEMVQR(qrString, amount string, opts ...AmountOption) (string, error) {
+	emvData, err := DecodeEMVQR(qrString)
+	if err != nil {
+		return "", err
+	}
+	if err := emvData.SetAmount(amount, opts...); err != nil {
+		return "", err
+	}
+	return emvData.Encode()
+}
+
+// ToStaticEMVQR converts a QR payload into a static one without an amount,
+// re-emitted with a fresh CRC.
+func ToStaticEMVQR(qrString string) (string, error) {
+	emvData, err := DecodeEMVQR(qrString)
+	if err != nil {
+		return "", err
+	}
+	emvData.ClearAmount()
+	return emvData.Encode()
+}
@@ -0,0 +1,143 @@
+package xstr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	staticPromptPayQR     = "00020101021129370016A0000006770101110113006681234567853037645802TH6304823E"
+	dynamicPromptPayQR    = "00020101021229370016A000000677010111011300668123456785303764540510.005802TH6304CA25"
+	dynamicPromptPayRefQR = "00020101021229370016A000000677010111011300668123456785303764540510.005802TH62210505INV010708TERM000163047D2D"
+	staticYenQR           = "00020101021129370016A0000006770101110113006681234567853033925802JP6304F9D4"
+	dynamicYenQR          = "00020101021229370016A000000677010111011300668123456785303392540415005802JP6304FBCF"
+)
+
+func TestToDynamicEMVQR(t *testing.T) {
+	tests := []struct {
+		name     string
+		qrString string
+		amount   string
+		opts     []AmountOption
+		expected string
+		wantErr  error
+	}{
+		{
+			name:     "static to dynamic",
+			qrString: staticPromptPayQR,
+			amount:   "10.00",
+			expected: dynamicPromptPayQR,
+		},
+		{
+			name:     "with additional data references",
+			qrString: staticPromptPayQR,
+			amount:   "10.00",
+			opts:     []AmountOption{WithReferenceLabel("INV01"), WithTerminalLabel("TERM0001")},
+			expected: dynamicPromptPayRefQR,
+		},
+		{
+			name:     "replace amount of dynamic QR",
+			qrString: dynamicYenQR,
+			amount:   "1500",
+			expected: dynamicYenQR,
+		},
+		{
+			name:     "zero minor units",
+			qrString: staticYenQR,
+			amount:   "1500",
+			expected: dynamicYenQR,
+		},
+		{
+			name:     "decimals not allowed for currency",
+			qrString: staticYenQR,
+			amount:   "1500.5",
+			wantErr:  ErrEMVInvalidAmount,
+		},
+		{
+			name:     "too many decimals",
+			qrString: staticPromptPayQR,
+			amount:   "10.001",
+			wantErr:  ErrEMVInvalidAmount,
+		},
+		{
+			name:     "thousands separator",
+			qrString: staticPromptPayQR,
+			amount:   "1,000.00",
+			wantErr:  ErrEMVInvalidAmount,
+		},
+		{
+			name:     "negative amount",
+			qrString: staticPromptPayQR,
+			amount:   "-5",
+			wantErr:  ErrEMVInvalidAmount,
+		},
+		{
+			name:     "zero amount",
+			qrString: staticPromptPayQR,
+			amount:   "0.00",
+			wantErr:  ErrEMVInvalidAmount,
+		},
+		{
+			name:     "too long",
+			qrString: staticPromptPayQR,
+			amount:   "12345678901.00",
+			wantErr:  ErrEMVInvalidAmount,
+		},
+		{
+			name:     "misplaced decimal separator",
+			qrString: staticPromptPayQR,
+			amount:   "10.",
+			wantErr:  ErrEMVInvalidAmount,
+		},
+		{
+			name:     "invalid CRC",
+			qrString: staticPromptPayQR[:len(staticPromptPayQR)-4] + "0000",
+			amount:   "10.00",
+			wantErr:  ErrEMVCRCMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ToDynamicEMVQR(tt.qrString, tt.amount, tt.opts...)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+			assert.NoError(t, VerifyEMVCRC(result))
+		})
+	}
+}
+
+func TestToStaticEMVQR(t *testing.T) {
+	result, err := ToStaticEMVQR(dynamicPromptPayQR)
+	require.NoError(t, err)
+	assert.Equal(t, staticPromptPayQR, result)
+
+	_, err = ToStaticEMVQR("000201")
+	assert.ErrorIs(t, err, ErrEMVCRCNotFound)
+}
+
+func TestEMVData_SetAmount(t *testing.T) {
+	emvData, err := DecodeEMVQR(staticPromptPayQR)
+	require.NoError(t, err)
+
+	require.NoError(t, emvData.SetAmount("99.5", WithBillNumber("A1"), WithAdditionalDataField("10", "TAX")))
+	assert.Equal(t, "99.5", emvData.TransactionAmount)
+	assert.Equal(t, "12", emvData.PointOfInitiationMethod)
+	assert.Equal(t, POITypeDynamic, emvData.POIMethodType)
+	assert.Equal(t, map[string]string{"01": "A1", "10": "TAX"}, emvData.AdditionalData)
+
+	emvData.ClearAmount()
+	assert.Empty(t, emvData.TransactionAmount)
+	assert.Equal(t, "11", emvData.PointOfInitiationMethod)
+	assert.Equal(t, POITypeStatic, emvData.POIMethodType)
+
+	emvData.TransactionCurrency = "999"
+	err = emvData.SetAmount("1.00")
+	assert.ErrorIs(t, err, ErrEMVUnknownCurrency)
+}
@@ -0,0 +1,186 @@
+package xstr
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrEMVValueTooLong is returned when a field value does not fit the 2-digit TLV length.
+var ErrEMVValueTooLong = errors.New("invalid EMV field: value longer than 99 characters")
+
+// EncodeEMVTLV encodes fields as consecutive TLV triplets in the given order.
+// The Length of each field is ignored and recomputed from its Value.
+//
+// Examples:
+//   - EncodeEMVTLV([]EMVDataValue{{Tag: "00", Value: "01"}}) -> "000201"
+func EncodeEMVTLV(fields []EMVDataValue) (string, error) {
+	var b strings.Builder
+	for _, field := range fields {
+		if err := writeEMVTLV(&b, field.Tag, field.Value); err != nil {
+			return "", err
+		}
+	}
+	return b.String(), nil
+}
+
+// Encode re-emits the EMV payload from the decoded fields, followed by a freshly computed CRC.
+// Fields are written in ascending tag order and sub-fields in ascending sub-tag order,
+// which reproduces conventionally ordered payloads exactly. The CRC field of e is ignored.
+// Amounts (tags 54 and 56) are validated as in DecodeEMVQR.
+func (e *EMVData) Encode() (string, error) {
+	if tag, err := e.checkAmounts(); err != nil {
+		return "", fmt.Errorf("tag %s: %w", tag, err)
+	}
+	fields, err := e.fields()
+	if err != nil {
+		return "", err
+	}
+	payload, err := EncodeEMVTLV(fields)
+	if err != nil {
+		return "", err
+	}
+	return AppendEMVCRC(payload), nil
+}
+
+// fields collects the top-level TLV fields of e in ascending tag order, excluding the CRC.
+func (e *EMVData) fields() ([]EMVDataValue, error) {
+	var fields []EMVDataValue
+	add := func(tag, value string) {
+		if value != "" {
+			fields = append(fields, EMVDataValue{Tag: tag, Length: len(value), Value: value})
+		}
+	}
+
+	add("00", e.PayloadFormatIndicator)
+	add("01", e.PointOfInitiationMethod)
+	for tag, account := range e.CardNetworkAccounts {
+		if account != nil {
+			add(tag, account.Value)
+		}
+	}
+	for tag, account := range e.MerchantAccountInfo {
+		if account == nil {
+			continue
+		}
+		value, err := account.encode()
+		if err != nil {
+			return nil, fmt.Errorf("merchant account %s: %w", tag, err)
+		}
+		add(tag, value)
+	}
+	add("52", e.MerchantCategoryCode)
+	add("53", e.TransactionCurrency)
+	add("54", e.TransactionAmount)
+	add("55", e.TipOrConvenienceIndicator)
+	add("56", e.ValueOfConvenienceFee)
+	add("58", e.CountryCode)
+	add("59", e.MerchantName)
+	add("60", e.MerchantCity)
+	add("61", e.PostalCode)
+	additionalData, err := encodeSubFields(e.AdditionalData)
+	if err != nil {
+		return nil, fmt.Errorf("additional data: %w", err)
+	}
+	add("62", additionalData)
+	for tag, value := range e.MerchantInformation {
+		add(tag, value)
+	}
+	for tag, value := range e.RFUData {
+		add(tag, value)
+	}
+	for tag, template := range e.UnreservedTemplates {
+		if template == nil {
+			continue
+		}
+		value, err := template.encode()
+		if err != nil {
+			return nil, fmt.Errorf("unreserved template %s: %w", tag, err)
+		}
+		add(tag, value)
+	}
+	for tag, value := range e.UnresolvedData {
+		add(tag, value)
+	}
+
+	sort.SliceStable(fields, func(i, j int) bool {
+		return fields[i].Tag < fields[j].Tag
+	})
+	return fields, nil
+}
+
+// encode rebuilds the merchant account template from its sub-fields.
+// Accounts without parsed sub-fields fall back to the original raw value.
+func (a *MerchantAccount) encode() (string, error) {
+	subFields := make(map[string]string, len(a.UnresolvedData)+5)
+	for tag, value := range a.UnresolvedData {
+		subFields[tag] = value
+	}
+	for tag, value := range map[string]string{
+		"00": a.AID,
+		"01": a.MerchantID,
+		"02": a.Reference1,
+		"03": a.Reference2,
+		"04": a.Reference3,
+	} {
+		if value != "" {
+			subFields[tag] = value
+		}
+	}
+
+	encoded, err := encodeSubFields(subFields)
+	if err != nil || encoded != "" {
+		return encoded, err
+	}
+	return a.RawValue, nil
+}
+
+// encode rebuilds the unreserved template from its GUI and sub-fields.
+// Templates without parsed sub-fields fall back to the original raw value.
+func (t *UnreservedTemplate) encode() (string, error) {
+	subFields := make(map[string]string, len(t.SubFields)+1)
+	for tag, value := range t.SubFields {
+		subFields[tag] = value
+	}
+	if t.GUI != "" {
+		subFields["00"] = t.GUI
+	}
+
+	encoded, err := encodeSubFields(subFields)
+	if err != nil || encoded != "" {
+		return encoded, err
+	}
+	return t.RawValue, nil
+}
+
+// encodeSubFields encodes a sub-field map in ascending sub-tag order.
+func encodeSubFields(subFields map[string]string) (string, error) {
+	tags := make([]string, 0, len(subFields))
+	for tag, value := range subFields {
+		if value != "" {
+			tags = append(tags, tag)
+		}
+	}
+	sort.Strings(tags)
+
+	var b strings.Builder
+	for _, tag := range tags {
+		if err := writeEMVTLV(&b, tag, subFields[tag]); err != nil {
+			return "", err
+		}
+	}
+	return b.String(), nil
+}
+
+// writeEMVTLV writes a single TLV triplet.
+func writeEMVTLV(b *strings.Builder, tag, value string) error {
+	if len(tag) != 2 {
+		return fmt.Errorf("%w: %q", ErrEMVInvalidTag, tag)
+	}
+	if len(value) > 99 {
+		return fmt.Errorf("%w: tag %s", ErrEMVValueTooLong, tag)
+	}
+	fmt.Fprintf(b, "%s%02d%s", tag, len(value), value)
+	return nil
+}
@@ -0,0 +1,123 @@
+package xstr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEMVData_Encode_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name      string
+		qrString  string
+		reordered bool
+	}{
+		{
+			name:     "tag 30 with additional data",
+			qrString: "00020101021130750016A00000067701011201150107537000882050219ZY010556UP8013305E80309MDMBEN38J53037645406900.045802TH622407200000yJMlWBD1ltXF6zJf6304858E",
+		},
+		{
+			name:     "dynamic tag 30",
+			qrString: "00020101021230870016A00000067701011201150205565052805020220ZYZRM7LJKIHW852LI6BJ0320LV182T0VX97RFFYNH7LK530376454031005802TH62240720PQRMGGT5EFY77KDP2QDI6304DBCF",
+		},
+		{
+			name:     "tag 62 with several sub-fields",
+			qrString: "00020101021230590016A000000677010112011501075370008820502061234560306ABCDEF530376454031005802TH62210105INV010708TERM00016304C43B",
+		},
+		{
+			name:     "language, RFU and unreserved templates",
+			qrString: "00020101021129370016A0000006770101110113006681234567853037645802TH64140002TH0104SHOP6504RFU180350014ID.CO.QRIS.WWW0106ID10200203UMI6304BB54",
+		},
+		{
+			name:      "out of order tags are canonicalized",
+			qrString:  "00020101021229370016A000000677010111021302455640030965802TH530376454071000.886304713E",
+			reordered: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			emvData, err := DecodeEMVQR(tt.qrString)
+			require.NoError(t, err)
+
+			encoded, err := emvData.Encode()
+			require.NoError(t, err)
+			if !tt.reordered {
+				assert.Equal(t, tt.qrString, encoded)
+			}
+
+			redecoded, err := DecodeEMVQR(encoded)
+			require.NoError(t, err)
+			redecoded.CRC = emvData.CRC
+			assert.Equal(t, emvData, redecoded)
+		})
+	}
+}
+
+func TestEMVData_Encode_Edited(t *testing.T) {
+	emvData, err := DecodeEMVQR("00020101021229370016A000000677010111021302455640030965802TH530376454071000.886304713E")
+	require.NoError(t, err)
+
+	emvData.TransactionAmount = "25.50"
+	emvData.MerchantAccountInfo["29"].MerchantID = "0066812345678"
+	emvData.CRC = "0000"
+
+	encoded, err := emvData.Encode()
+	require.NoError(t, err)
+	assert.NoError(t, VerifyEMVCRC(encoded))
+
+	decoded, err := DecodeEMVQR(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, "25.50", decoded.TransactionAmount)
+	assert.Equal(t, "0066812345678", decoded.MerchantAccountInfo["29"].MerchantID)
+	assert.Equal(t, "0245564003096", decoded.MerchantAccountInfo["29"].Reference1)
+}
+
+func TestEMVData_Encode_ValueTooLong(t *testing.T) {
+	emvData := &EMVData{
+		PayloadFormatIndicator: "01",
+		MerchantName:           strings.Repeat("A", 100),
+	}
+
+	_, err := emvData.Encode()
+	assert.ErrorIs(t, err, ErrEMVValueTooLong)
+}
+
+func TestEncodeEMVTLV(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  []EMVDataValue
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "single field",
+			fields: []EMVDataValue{{Tag: "00", Value: "01"}},
+			want:   "000201",
+		},
+		{
+			name:   "keeps given order and recomputes length",
+			fields: []EMVDataValue{{Tag: "58", Length: 99, Value: "TH"}, {Tag: "00", Value: "01"}},
+			want:   "5802TH000201",
+		},
+		{
+			name:    "invalid tag",
+			fields:  []EMVDataValue{{Tag: "0", Value: "01"}},
+			wantErr: ErrEMVInvalidTag,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EncodeEMVTLV(tt.fields)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package xstr
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Common EMV expiry errors.
+var (
+	ErrEMVInvalidTimestamp = errors.New("invalid EMV QR code: invalid timestamp")
+	ErrEMVQRExpired        = errors.New("invalid EMV QR code: expired")
+	ErrEMVQRNotYetValid    = errors.New("invalid EMV QR code: not yet valid")
+)
+
+// payNowLocation is the time zone of PayNow expiry dates (Singapore, UTC+8).
+var payNowLocation = time.FixedZone("SGT", 8*60*60)
+
+// thaiLocation is the time zone of Thai bank expiry fields (Thailand, UTC+7).
+var thaiLocation = time.FixedZone("ICT", 7*60*60)
+
+// Clock returns the current time. Decoders take a Clock so tests can fix the time;
+// a nil Clock means time.Now.
+type Clock func() time.Time
+
+// QRValidity is the validity window embedded in a dynamic QR.
+// A nil bound is open: a QR without ExpiresAt never expires.
+type QRValidity struct {
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"` // Exclusive: the QR is expired from this instant
+	Source    string     `json:"source"`               // Field the window was read from, e.g. "26.04"
+}
+
+// ValidAt reports whether t falls inside the validity window.
+func (v *QRValidity) ValidAt(t time.Time) bool {
+	if v.CreatedAt != nil && t.Before(*v.CreatedAt) {
+		return false
+	}
+	return v.ExpiresAt == nil || t.Before(*v.ExpiresAt)
+}
+
+// ExpiryParser extracts the validity window of one QR format. It returns nil without
+// an error when the QR does not carry its fields, so parsers can be chained. Formats
+// without a built-in parser are supported by passing custom parsers to Validity,
+// IsExpired, ValidAt, PaymentIntentWithExpiry or WithExpiryCheck.
+type ExpiryParser func(e *EMVData) (*QRValidity, error)
+
+// DefaultExpiryParsers are the parsers used when none are given:
+// PayNow expiry dates and KHQR timestamps. Thai bank-specific expiry fields have no
+// published common layout, so they are read by ThaiBankExpiryParser configured with
+// the layout the bank documents. DefaultExpiryParsers must not be modified; pass
+// parsers to Validity, IsExpired, ValidAt or WithExpiryCheck instead.
+var DefaultExpiryParsers = []ExpiryParser{ParsePayNowExpiry, ParseKHQRTimestamps}
+
+// Validity returns the validity window of the QR using the first parser that recognizes it,
+// or nil if the QR carries no known expiry. DefaultExpiryParsers are used when no parsers are given.
+func (e *EMVData) Validity(parsers ...ExpiryParser) (*QRValidity, error) {
+	if len(parsers) == 0 {
+		parsers = DefaultExpiryParsers
+	}
+	for _, parse := range parsers {
+		validity, err := parse(e)
+		if err != nil || validity != nil {
+			return validity, err
+		}
+	}
+	return nil, nil
+}
+
+// IsExpired reports whether the QR has expired at now, reading the validity window with
+// the given parsers or DefaultExpiryParsers. QRs with a malformed expiry are reported as
+// expired so they are never paid by mistake.
+func (e *EMVData) IsExpired(now time.Time, parsers ...ExpiryParser) bool {
+	validity, err := e.Validity(parsers...)
+	if err != nil {
+		return true
+	}
+	return validity != nil && validity.ExpiresAt != nil && !now.Before(*validity.ExpiresAt)
+}
+
+// ValidAt reports whether the QR may be paid at t: it is neither expired nor created after t.
+// The validity window is read like IsExpired. QRs without a known expiry are always valid;
+// QRs with a malformed expiry never are.
+func (e *EMVData) ValidAt(t time.Time, parsers ...ExpiryParser) bool {
+	validity, err := e.Validity(parsers...)
+	if err != nil {
+		return false
+	}
+	return validity == nil || validity.ValidAt(t)
+}
+
+// ThaiBankExpiryParser returns a parser for a Thai bank's expiry field: the additional data
+// (tag 62) sub-field subTag of a QR with country code TH, parsed with the time layout in
+// Thai time (UTC+7). The QR is expired from the parsed instant.
+//
+// Examples:
+//   - ThaiBankExpiryParser("09", "20060102150405"), sub-field 09 "20261231235959" ->
+//     ExpiresAt 2026-12-31 23:59:59 ICT
+func ThaiBankExpiryParser(subTag, layout string) ExpiryParser {
+	return func(e *EMVData) (*QRValidity, error) {
+		value := e.AdditionalData[subTag]
+		if value == "" || e.CountryCode != "TH" {
+			return nil, nil
+		}
+		expiresAt, err := time.ParseInLocation(layout, value, thaiLocation)
+		if err != nil {
+			return nil, fmt.Errorf("%w: Thai bank expiry %q in tag 62.%s", ErrEMVInvalidTimestamp, value, subTag)
+		}
+		return &QRValidity{ExpiresAt: &expiresAt, Source: "62." + subTag}, nil
+	}
+}
+
+// ParsePayNowExpiry reads the expiry date of a PayNow account (GUI "SG.PAYNOW", sub-field 04).
+// The date is "YYYYMMDD" in Singapore time and the QR stays valid until the end of that day;
+// "YYYYMMDDhhmmss" is accepted for a precise expiry.
+//
+// Examples:
+//   - sub-field 04 "20261231" -> ExpiresAt 2027-01-01 00:00 SGT
+func ParsePayNowExpiry(e *EMVData) (*QRValidity, error) {
+	for _, tag := range sortedAccountTags(e) {
+		account := e.MerchantAccountInfo[tag]
+		if account.PaymentScheme != QRSchemePayNow || account.Reference3 == "" {
+			continue
+		}
+
+		value := account.Reference3
+		var expiresAt time.Time
+		var err error
+		switch len(value) {
+		case 8:
+			expiresAt, err = time.ParseInLocation("20060102", value, payNowLocation)
+			expiresAt = expiresAt.AddDate(0, 0, 1)
+		case 14:
+			expiresAt, err = time.ParseInLocation("20060102150405", value, payNowLocation)
+		default:
+			err = errors.New("unexpected length")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: PayNow expiry %q in tag %s", ErrEMVInvalidTimestamp, value, tag)
+		}
+		return &QRValidity{ExpiresAt: &expiresAt, Source: tag + ".04"}, nil
+	}
+	return nil, nil
+}
+
+// ParseKHQRTimestamps reads the KHQR timestamp template (tag 99): sub-field 00 holds the
+// creation time and sub-field 01 the expiry time, both as 13-digit Unix milliseconds.
+func ParseKHQRTimestamps(e *EMVData) (*QRValidity, error) {
+	template := e.UnreservedTemplates["99"]
+	if template == nil || e.CountryCode != "KH" {
+		return nil, nil
+	}
+
+	// KHQR defines sub-field 00 as a timestamp, not a GUI, so the sub-fields are read from the raw value
+	subFields, err := parseSubFields(template.RawValue)
+	if err != nil {
+		return nil, fmt.Errorf("%w: KHQR timestamp template in tag 99: %v", ErrEMVInvalidTimestamp, err)
+	}
+
+	validity := &QRValidity{Source: "99"}
+	timestamps := []struct {
+		subTag string
+		value  string
+		dst    **time.Time
+	}{
+		{"00", subFields["00"], &validity.CreatedAt},
+		{"01", subFields["01"], &validity.ExpiresAt},
+	}
+	for _, timestamp := range timestamps {
+		if timestamp.value == "" {
+			continue
+		}
+		millis, err := strconv.ParseInt(timestamp.value, 10, 64)
+		if err != nil || len(timestamp.value) != 13 {
+			return nil, fmt.Errorf("%w: KHQR timestamp %q in tag 99.%s", ErrEMVInvalidTimestamp, timestamp.value, timestamp.subTag)
+		}
+		t := time.UnixMilli(millis).UTC()
+		*timestamp.dst = &t
+	}
+	if validity.CreatedAt == nil && validity.ExpiresAt == nil {
+		return nil, nil
+	}
+	return validity, nil
+}
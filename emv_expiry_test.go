@@ -0,0 +1,301 @@
+package xstr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	expiryPayNowQR        = "00020101021226490009SG.PAYNOW010120210201403121W03010040820261231520400005303702540512.505802SG5912ACME PTE LTD6009Singapore6304B916"
+	expiryPayNowInvalidQR = "00020101021226480009SG.PAYNOW010120210201403121W0301004072026123520400005303702540512.505802SG5912ACME PTE LTD6009Singapore6304FA66"
+	expiryThaiBankQR      = "00020101021229370016A00000067701011101130066812345678530376454031005802TH621809142026123123595963041AF1"
+	expiryThaiInvalidQR   = "00020101021229370016A00000067701011101130066812345678530376454031005802TH621209082026133163043F8C"
+	expiryKHQR            = "00020101021229190015john_smith@devb5303116540450005802KH5910John Smith6010PHNOM PENH993400131760000000000011317600006000006304FC59"
+)
+
+func TestEMVData_Validity(t *testing.T) {
+	payNowExpiry := time.Date(2027, 1, 1, 0, 0, 0, 0, payNowLocation)
+	khqrCreated := time.Date(2025, 10, 9, 8, 53, 20, 0, time.UTC)
+	khqrExpiry := khqrCreated.Add(10 * time.Minute)
+
+	tests := []struct {
+		name     string
+		qrString string
+		expected *QRValidity
+		wantErr  error
+	}{
+		{
+			name:     "PayNow expiry date",
+			qrString: expiryPayNowQR,
+			expected: &QRValidity{ExpiresAt: &payNowExpiry, Source: "26.04"},
+		},
+		{
+			name:     "KHQR timestamps",
+			qrString: expiryKHQR,
+			expected: &QRValidity{CreatedAt: &khqrCreated, ExpiresAt: &khqrExpiry, Source: "99"},
+		},
+		{
+			name:     "no expiry",
+			qrString: staticPromptPayQR,
+		},
+		{
+			name:     "malformed PayNow expiry",
+			qrString: expiryPayNowInvalidQR,
+			wantErr:  ErrEMVInvalidTimestamp,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			emvData, err := DecodeEMVQR(tt.qrString)
+			require.NoError(t, err)
+
+			validity, err := emvData.Validity()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, validity)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, validity)
+		})
+	}
+}
+
+func TestEMVData_IsExpired(t *testing.T) {
+	tests := []struct {
+		name     string
+		qrString string
+		at       time.Time
+		expired  bool
+		valid    bool
+	}{
+		{
+			name:     "PayNow on the expiry date",
+			qrString: expiryPayNowQR,
+			at:       time.Date(2026, 12, 31, 23, 59, 0, 0, payNowLocation),
+			valid:    true,
+		},
+		{
+			name:     "PayNow after the expiry date",
+			qrString: expiryPayNowQR,
+			at:       time.Date(2027, 1, 1, 0, 0, 0, 0, payNowLocation),
+			expired:  true,
+		},
+		{
+			name:     "KHQR within its window",
+			qrString: expiryKHQR,
+			at:       time.Date(2025, 10, 9, 9, 0, 0, 0, time.UTC),
+			valid:    true,
+		},
+		{
+			name:     "KHQR before creation",
+			qrString: expiryKHQR,
+			at:       time.Date(2025, 10, 9, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "KHQR after expiry",
+			qrString: expiryKHQR,
+			at:       time.Date(2025, 10, 9, 9, 3, 20, 0, time.UTC),
+			expired:  true,
+		},
+		{
+			name:     "no expiry",
+			qrString: staticPromptPayQR,
+			at:       time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC),
+			valid:    true,
+		},
+		{
+			name:     "malformed expiry is never payable",
+			qrString: expiryPayNowInvalidQR,
+			at:       time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+			expired:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			emvData, err := DecodeEMVQR(tt.qrString)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expired, emvData.IsExpired(tt.at))
+			assert.Equal(t, tt.valid, emvData.ValidAt(tt.at))
+		})
+	}
+}
+
+func TestParseKHQRTimestamps_ReadsRawTemplate(t *testing.T) {
+	// Sub-field 00 is read from the raw template, not from the GUI field
+	emvData := &EMVData{
+		CountryCode: "KH",
+		UnreservedTemplates: map[string]*UnreservedTemplate{
+			"99": {RawValue: "00131760000000000", PaymentScheme: QRSchemeUnknown},
+		},
+	}
+
+	validity, err := ParseKHQRTimestamps(emvData)
+	require.NoError(t, err)
+	require.NotNil(t, validity)
+	assert.Equal(t, time.Date(2025, 10, 9, 8, 53, 20, 0, time.UTC), *validity.CreatedAt)
+	assert.Nil(t, validity.ExpiresAt)
+
+	emvData.UnreservedTemplates["99"].RawValue = "0013176"
+	_, err = ParseKHQRTimestamps(emvData)
+	assert.ErrorIs(t, err, ErrEMVInvalidTimestamp)
+}
+
+func TestThaiBankExpiryParser(t *testing.T) {
+	parser := ThaiBankExpiryParser("09", "20060102150405")
+	expiresAt := time.Date(2026, 12, 31, 23, 59, 59, 0, thaiLocation)
+
+	tests := []struct {
+		name     string
+		qrString string
+		expected *QRValidity
+		wantErr  error
+	}{
+		{
+			name:     "expiry in tag 62",
+			qrString: expiryThaiBankQR,
+			expected: &QRValidity{ExpiresAt: &expiresAt, Source: "62.09"},
+		},
+		{
+			name:     "no expiry field",
+			qrString: staticPromptPayQR,
+		},
+		{
+			name:     "malformed expiry",
+			qrString: expiryThaiInvalidQR,
+			wantErr:  ErrEMVInvalidTimestamp,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			emvData, err := DecodeEMVQR(tt.qrString)
+			require.NoError(t, err)
+
+			validity, err := emvData.Validity(parser)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, validity)
+		})
+	}
+}
+
+func TestEMVData_IsExpired_CustomParsers(t *testing.T) {
+	emvData, err := DecodeEMVQR(expiryThaiBankQR)
+	require.NoError(t, err)
+	parser := ThaiBankExpiryParser("09", "20060102150405")
+	after := time.Date(2027, 1, 1, 0, 0, 0, 0, thaiLocation)
+
+	// Without the bank's parser the QR carries no known expiry
+	assert.False(t, emvData.IsExpired(after))
+	assert.True(t, emvData.ValidAt(after))
+
+	assert.True(t, emvData.IsExpired(after, parser))
+	assert.False(t, emvData.ValidAt(after, parser))
+	assert.True(t, emvData.ValidAt(after.Add(-time.Hour), parser))
+
+	intent, err := emvData.PaymentIntentWithExpiry(parser)
+	require.NoError(t, err)
+	require.NotNil(t, intent.ExpiresAt)
+	assert.True(t, intent.ExpiresAt.Equal(time.Date(2026, 12, 31, 23, 59, 59, 0, thaiLocation)))
+}
+
+func TestDecoder_WithExpiryCheck(t *testing.T) {
+	clockAt := func(t time.Time) Clock {
+		return func() time.Time { return t }
+	}
+
+	tests := []struct {
+		name     string
+		qrString string
+		clock    Clock
+		wantErr  error
+	}{
+		{
+			name:     "valid",
+			qrString: expiryKHQR,
+			clock:    clockAt(time.Date(2025, 10, 9, 9, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:     "expired",
+			qrString: expiryKHQR,
+			clock:    clockAt(time.Date(2025, 10, 10, 0, 0, 0, 0, time.UTC)),
+			wantErr:  ErrEMVQRExpired,
+		},
+		{
+			name:     "not yet valid",
+			qrString: expiryKHQR,
+			clock:    clockAt(time.Date(2025, 10, 8, 0, 0, 0, 0, time.UTC)),
+			wantErr:  ErrEMVQRNotYetValid,
+		},
+		{
+			name:     "malformed timestamp",
+			qrString: expiryPayNowInvalidQR,
+			wantErr:  ErrEMVInvalidTimestamp,
+		},
+		{
+			name:     "no expiry with the default clock",
+			qrString: staticPromptPayQR,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			emvData, err := NewDecoder(WithExpiryCheck(tt.clock)).Decode(tt.qrString)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, emvData)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, emvData)
+		})
+	}
+}
+
+func TestDecoder_WithExpiryCheck_CustomParser(t *testing.T) {
+	expired := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	bankParser := func(e *EMVData) (*QRValidity, error) {
+		if e.AdditionalData["05"] == "" {
+			return nil, nil
+		}
+		return &QRValidity{ExpiresAt: &expired, Source: "62.05"}, nil
+	}
+
+	decoder := NewDecoder(WithExpiryCheck(nil, bankParser))
+	_, err := decoder.Decode(intentMobileQR)
+	assert.ErrorIs(t, err, ErrEMVQRExpired)
+
+	_, err = decoder.Decode(staticPromptPayQR)
+	assert.NoError(t, err)
+}
+
+func TestEMVData_PaymentIntent_ExpiresAt(t *testing.T) {
+	emvData, err := DecodeEMVQR(expiryPayNowQR)
+	require.NoError(t, err)
+
+	intent, err := emvData.PaymentIntent()
+	require.NoError(t, err)
+	require.NotNil(t, intent.ExpiresAt)
+	assert.True(t, intent.ExpiresAt.Equal(time.Date(2026, 12, 31, 16, 0, 0, 0, time.UTC)))
+	assert.Equal(t, QRSchemePayNow, intent.Scheme)
+	assert.Equal(t, "201403121W", intent.PayeeID)
+	assert.Equal(t, PayeeTypeUEN, intent.PayeeType)
+	assert.Empty(t, intent.Reference1)
+	assert.Empty(t, intent.Reference2)
+	assert.Equal(t, int64(1250), intent.AmountMinor)
+	assert.Equal(t, "SGD", intent.Currency)
+
+	emvData, err = DecodeEMVQR(expiryPayNowInvalidQR)
+	require.NoError(t, err)
+	_, err = emvData.PaymentIntent()
+	assert.ErrorIs(t, err, ErrEMVInvalidTimestamp)
+}
@@ -0,0 +1,331 @@
+package xstr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ExplainOption configures ExplainEMVQR.
+type ExplainOption func(*explainConfig)
+
+// explainConfig holds the ExplainEMVQR options.
+type explainConfig struct {
+	markdown bool
+	mask     bool
+}
+
+// WithExplainMarkdown renders the explanation as a nested Markdown list.
+func WithExplainMarkdown() ExplainOption {
+	return func(c *explainConfig) {
+		c.markdown = true
+	}
+}
+
+// WithExplainMasking masks account identifiers with MaskSensitive.
+// Lengths are still reported for the original values.
+func WithExplainMasking() ExplainOption {
+	return func(c *explainConfig) {
+		c.mask = true
+	}
+}
+
+// emvExplainNode is an annotated TLV field.
+type emvExplainNode struct {
+	tag       string
+	name      string
+	length    int
+	value     string // Empty for templates that parsed into sub-fields
+	meaning   string
+	subFields []emvExplainNode
+}
+
+// ExplainEMVQR renders an EMV payload as an indented, annotated tree with the tag,
+// field name, length, value and interpreted meaning of every field and sub-field.
+// The payload is parsed with ParseEMVTLV, so a payload with a broken CRC can still be explained.
+//
+// Example output:
+//
+//	01 Point of Initiation Method (02): 12 = dynamic
+//	29 Merchant Account Information (37) = PromptPay
+//	  00 Globally Unique Identifier (16): A000000677010111 = PromptPay C2C
+//	  01 Mobile Number (13): 0066812345678
+//	53 Transaction Currency (03): 764 = THB
+func ExplainEMVQR(qrString string, opts ...ExplainOption) (string, error) {
+	config := &explainConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	fields, err := ParseEMVTLV(qrString)
+	if err != nil {
+		return "", err
+	}
+
+	nodes := make([]emvExplainNode, 0, len(fields))
+	for _, field := range fields {
+		nodes = append(nodes, explainEMVField(qrString, field, config))
+	}
+
+	var b strings.Builder
+	writeExplainNodes(&b, nodes, 0, config.markdown)
+	return b.String(), nil
+}
+
+// explainEMVField annotates a top-level field, descending into templates.
+func explainEMVField(qrString string, field EMVDataValue, config *explainConfig) emvExplainNode {
+	node := emvExplainNode{
+		tag:    field.Tag,
+		name:   emvFieldName(field.Tag),
+		length: field.Length,
+		value:  field.Value,
+	}
+	isAccount := field.Tag >= "02" && field.Tag <= "51"
+	if field.Tag <= "25" && isAccount {
+		node.meaning = string(mapCardNetwork(field.Tag))
+	}
+
+	switch field.Tag {
+	case "01":
+		if poiType := mapPOIMethodType(field.Value); poiType != POITypeUnknown {
+			node.meaning = string(poiType)
+		}
+	case "53":
+		if currency, ok := lookupCurrency(field.Value); ok {
+			node.meaning = currency.Alpha
+		}
+	case "55":
+		node.meaning = tipIndicatorMeaning(field.Value)
+	case "63":
+		if err := VerifyEMVCRC(qrString); err != nil {
+			node.meaning = fmt.Sprintf("invalid (%v)", err)
+		} else {
+			node.meaning = "valid"
+		}
+	}
+
+	if !isEMVTemplateTag(field.Tag) {
+		if isAccount && config.mask {
+			node.value = MaskSensitive(node.value)
+		}
+		return node
+	}
+
+	var subFields []EMVDataValue
+	for subField, err := range EMVTLVSeq(field.Value) {
+		if err != nil {
+			node.meaning = "malformed template"
+			if config.mask && isAccount {
+				node.value = MaskSensitive(node.value)
+			}
+			return node
+		}
+		subFields = append(subFields, subField)
+	}
+
+	var scheme QRPaymentScheme
+	for _, subField := range subFields {
+		if subField.Tag == "00" {
+			scheme = mapScheme(subField.Value)
+		}
+	}
+	isGUITemplate := isAccount || (field.Tag >= "80" && field.Tag <= "99")
+	if isGUITemplate && scheme != "" && scheme != QRSchemeUnknown {
+		node.meaning = string(scheme)
+	}
+
+	node.value = ""
+	for _, subField := range subFields {
+		child := emvExplainNode{
+			tag:    subField.Tag,
+			name:   emvSubFieldName(field.Tag, subField.Tag, scheme),
+			length: subField.Length,
+			value:  subField.Value,
+		}
+		switch {
+		case isGUITemplate && subField.Tag == "00":
+			child.meaning = guiMeaning(subField.Value)
+		case isGUITemplate && config.mask:
+			child.value = MaskSensitive(child.value)
+		case field.Tag == "62" && subField.Tag == "02" && config.mask:
+			child.value = MaskSensitive(child.value)
+		}
+		node.subFields = append(node.subFields, child)
+	}
+	return node
+}
+
+// writeExplainNodes renders nodes as plain text or Markdown at the given depth.
+func writeExplainNodes(b *strings.Builder, nodes []emvExplainNode, depth int, markdown bool) {
+	for _, node := range nodes {
+		if markdown {
+			fmt.Fprintf(b, "%s- **%s** %s (%02d)", strings.Repeat("  ", depth), node.tag, node.name, node.length)
+			if node.value != "" || node.subFields == nil {
+				fmt.Fprintf(b, ": %s", markdownCode(node.value))
+			}
+		} else {
+			fmt.Fprintf(b, "%s%s %s (%02d)", strings.Repeat("  ", depth), node.tag, node.name, node.length)
+			if node.value != "" || node.subFields == nil {
+				fmt.Fprintf(b, ": %s", node.value)
+			}
+		}
+		if node.meaning != "" {
+			fmt.Fprintf(b, " = %s", node.meaning)
+		}
+		b.WriteByte('\n')
+		writeExplainNodes(b, node.subFields, depth+1, markdown)
+	}
+}
+
+// markdownCode wraps a value in a code span, widening the fence if the value contains backticks.
+func markdownCode(value string) string {
+	if strings.Contains(value, "`") {
+		return "`` " + value + " ``"
+	}
+	return "`" + value + "`"
+}
+
+// emvFieldName returns the EMV MPM name of a top-level tag.
+func emvFieldName(tag string) string {
+	switch {
+	case tag == "00":
+		return "Payload Format Indicator"
+	case tag == "01":
+		return "Point of Initiation Method"
+	case tag >= "02" && tag <= "51":
+		return "Merchant Account Information"
+	case tag == "52":
+		return "Merchant Category Code"
+	case tag == "53":
+		return "Transaction Currency"
+	case tag == "54":
+		return "Transaction Amount"
+	case tag == "55":
+		return "Tip or Convenience Indicator"
+	case tag == "56":
+		return "Value of Convenience Fee Fixed"
+	case tag == "57":
+		return "Value of Convenience Fee Percentage"
+	case tag == "58":
+		return "Country Code"
+	case tag == "59":
+		return "Merchant Name"
+	case tag == "60":
+		return "Merchant City"
+	case tag == "61":
+		return "Postal Code"
+	case tag == "62":
+		return "Additional Data Field Template"
+	case tag == "63":
+		return "CRC"
+	case tag == "64":
+		return "Merchant Information Language Template"
+	case tag >= "65" && tag <= "79":
+		return "RFU for EMVCo"
+	case tag >= "80" && tag <= "99":
+		return "Unreserved Template"
+	default:
+		return "Unknown"
+	}
+}
+
+// emvSubFieldName returns the name of a sub-field within a template.
+// Merchant account sub-fields are named after the PromptPay layout when the scheme is PromptPay.
+func emvSubFieldName(tag, subTag string, scheme QRPaymentScheme) string {
+	if subTag == "00" && tag != "62" && tag != "64" {
+		return "Globally Unique Identifier"
+	}
+
+	switch {
+	case tag == "62":
+		return additionalDataFieldName(subTag)
+	case tag == "64":
+		switch subTag {
+		case "00":
+			return "Language Preference"
+		case "01":
+			return "Merchant Name (Alternate Language)"
+		case "02":
+			return "Merchant City (Alternate Language)"
+		}
+		return "RFU for EMVCo"
+	case tag == "29" && scheme == QRSchemePromptPay:
+		switch mapPromptPayProxyType(subTag) {
+		case PromptPayProxyMobile:
+			return "Mobile Number"
+		case PromptPayProxyNationalID:
+			return "National ID / Tax ID"
+		case PromptPayProxyEWallet:
+			return "E-Wallet ID"
+		case PromptPayProxyBankAccount:
+			return "Bank Account"
+		}
+	case tag == "30" && scheme == QRSchemePromptPay:
+		switch subTag {
+		case "01":
+			return "Biller ID"
+		case "02":
+			return "Reference 1"
+		case "03":
+			return "Reference 2"
+		}
+	}
+	return "Payment Network Specific"
+}
+
+// additionalDataFieldName returns the name of a tag 62 sub-field.
+func additionalDataFieldName(subTag string) string {
+	switch subTag {
+	case "01":
+		return "Bill Number"
+	case "02":
+		return "Mobile Number"
+	case "03":
+		return "Store Label"
+	case "04":
+		return "Loyalty Number"
+	case "05":
+		return "Reference Label"
+	case "06":
+		return "Customer Label"
+	case "07":
+		return "Terminal Label"
+	case "08":
+		return "Purpose of Transaction"
+	case "09":
+		return "Additional Consumer Data Request"
+	case "10":
+		return "Merchant Tax ID"
+	case "11":
+		return "Merchant Channel"
+	}
+	if subTag >= "50" && subTag <= "99" {
+		return "Payment System Specific Template"
+	}
+	return "RFU for EMVCo"
+}
+
+// guiMeaning describes a globally unique identifier by scheme and PromptPay AID type.
+func guiMeaning(gui string) string {
+	scheme := mapScheme(gui)
+	if scheme == QRSchemeUnknown {
+		return ""
+	}
+	if aidType := mapAIDType(gui); aidType != QRTypeUnknown {
+		return string(scheme) + " " + string(aidType)
+	}
+	return string(scheme)
+}
+
+// tipIndicatorMeaning describes the tip or convenience indicator (tag 55).
+func tipIndicatorMeaning(value string) string {
+	switch value {
+	case "01":
+		return "consumer prompted for tip"
+	case "02":
+		return "fixed convenience fee"
+	case "03":
+		return "percentage convenience fee"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,93 @@
+package xstr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainEMVQR(t *testing.T) {
+	tests := []struct {
+		name     string
+		qrString string
+		opts     []ExplainOption
+		expected string
+	}{
+		{
+			name:     "promptpay mobile",
+			qrString: "00020101021129370016A000000677010111011300668123456785802TH5303764540510.0063044ABE",
+			expected: "00 Payload Format Indicator (02): 01\n" +
+				"01 Point of Initiation Method (02): 11 = static\n" +
+				"29 Merchant Account Information (37) = PromptPay\n" +
+				"  00 Globally Unique Identifier (16): A000000677010111 = PromptPay C2C\n" +
+				"  01 Mobile Number (13): 0066812345678\n" +
+				"58 Country Code (02): TH\n" +
+				"53 Transaction Currency (03): 764 = THB\n" +
+				"54 Transaction Amount (05): 10.00\n" +
+				"63 CRC (04): 4ABE = valid\n",
+		},
+		{
+			name:     "masked bill payment",
+			qrString: "00020101021230590016A000000677010112011501075370008820502061234560306ABCDEF530376454031005802TH62210105INV010708TERM00016304C43B",
+			opts:     []ExplainOption{WithExplainMasking()},
+			expected: "00 Payload Format Indicator (02): 01\n" +
+				"01 Point of Initiation Method (02): 12 = dynamic\n" +
+				"30 Merchant Account Information (59) = PromptPay\n" +
+				"  00 Globally Unique Identifier (16): A000000677010112 = PromptPay C2B\n" +
+				"  01 Biller ID (15): 0107****8205\n" +
+				"  02 Reference 1 (06): ****56\n" +
+				"  03 Reference 2 (06): ****EF\n" +
+				"53 Transaction Currency (03): 764 = THB\n" +
+				"54 Transaction Amount (03): 100\n" +
+				"58 Country Code (02): TH\n" +
+				"62 Additional Data Field Template (21)\n" +
+				"  01 Bill Number (05): INV01\n" +
+				"  07 Terminal Label (08): TERM0001\n" +
+				"63 CRC (04): C43B = valid\n",
+		},
+		{
+			name:     "markdown",
+			qrString: "00020101021129370016A000000677010111011300668123456785802TH5303764540510.0063044ABE",
+			opts:     []ExplainOption{WithExplainMarkdown(), WithExplainMasking()},
+			expected: "- **00** Payload Format Indicator (02): `01`\n" +
+				"- **01** Point of Initiation Method (02): `11` = static\n" +
+				"- **29** Merchant Account Information (37) = PromptPay\n" +
+				"  - **00** Globally Unique Identifier (16): `A000000677010111` = PromptPay C2C\n" +
+				"  - **01** Mobile Number (13): `0066****5678`\n" +
+				"- **58** Country Code (02): `TH`\n" +
+				"- **53** Transaction Currency (03): `764` = THB\n" +
+				"- **54** Transaction Amount (05): `10.00`\n" +
+				"- **63** CRC (04): `4ABE` = valid\n",
+		},
+		{
+			name:     "invalid CRC and unknown fields",
+			qrString: "0002010102117001X6304FFFF",
+			expected: "00 Payload Format Indicator (02): 01\n" +
+				"01 Point of Initiation Method (02): 11 = static\n" +
+				"70 RFU for EMVCo (01): X\n" +
+				"63 CRC (04): FFFF = invalid (invalid CRC: expected F03A, got FFFF)\n",
+		},
+		{
+			name:     "malformed template",
+			qrString: "0002010102112603ABC",
+			opts:     []ExplainOption{WithExplainMasking()},
+			expected: "00 Payload Format Indicator (02): 01\n" +
+				"01 Point of Initiation Method (02): 11 = static\n" +
+				"26 Merchant Account Information (03): **** = malformed template\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExplainEMVQR(tt.qrString, tt.opts...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestExplainEMVQR_Error(t *testing.T) {
+	_, err := ExplainEMVQR("0005AB")
+	assert.ErrorIs(t, err, ErrEMVInvalidData)
+}
@@ -0,0 +1,158 @@
+package xstr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// InteropType classifies a payment by where the QR was issued and where it is paid.
+type InteropType string
+
+// Interop type constants
+const (
+	InteropDomestic    InteropType = "domestic"     // QR issued in the acquiring country
+	InteropCrossBorder InteropType = "cross_border" // QR issued abroad and paid through a linkage
+)
+
+// Common interoperability errors.
+var (
+	ErrInteropMissingCountry = errors.New("interop: missing country code")
+	ErrNoQRLinkage           = errors.New("interop: no linkage for cross-border payment")
+)
+
+// QRLinkage is a one-directional cross-border QR linkage: consumers of the acquirer
+// country may pay QRs of the merchant scheme issued in the merchant country.
+type QRLinkage struct {
+	Name            string          `json:"name"`             // e.g. "PromptPay-PayNow"
+	MerchantCountry string          `json:"merchant_country"` // ISO 3166-1 alpha-2 country of the QR (tag 58)
+	MerchantScheme  QRPaymentScheme `json:"merchant_scheme"`  // Scheme of the merchant account in the QR
+	AcquirerCountry string          `json:"acquirer_country"` // ISO 3166-1 alpha-2 country of the paying app
+	AcquirerScheme  QRPaymentScheme `json:"acquirer_scheme"`  // Scheme the payment is routed through at home
+	DebitCurrency   string          `json:"debit_currency"`   // ISO 4217 numeric currency the consumer is debited in
+}
+
+// InteropResult describes how a decoded QR is paid from the acquiring country.
+type InteropResult struct {
+	Type             InteropType     `json:"type"`
+	MerchantCountry  string          `json:"merchant_country"`
+	AcquirerCountry  string          `json:"acquirer_country"`
+	MerchantScheme   QRPaymentScheme `json:"merchant_scheme"`
+	MerchantCurrency string          `json:"merchant_currency"` // Transaction currency of the QR (tag 53)
+	DebitCurrency    string          `json:"debit_currency"`    // Currency the consumer is debited in
+	Linkage          *QRLinkage      `json:"linkage,omitempty"` // Applied linkage, nil for domestic payments
+}
+
+// LinkageTable is an offline, immutable table of cross-border linkages.
+// A LinkageTable is safe for concurrent use.
+type LinkageTable struct {
+	linkages []QRLinkage
+}
+
+// NewLinkageTable creates a table from the given linkages.
+// Earlier linkages take precedence when several match a QR.
+func NewLinkageTable(linkages ...QRLinkage) *LinkageTable {
+	table := &LinkageTable{linkages: make([]QRLinkage, len(linkages))}
+	for i, linkage := range linkages {
+		linkage.MerchantCountry = normalizeCountryCode(linkage.MerchantCountry)
+		linkage.AcquirerCountry = normalizeCountryCode(linkage.AcquirerCountry)
+		table.linkages[i] = linkage
+	}
+	return table
+}
+
+// BilateralQRLinkage returns the two directions of a linkage between two schemes.
+//
+// Examples:
+//   - BilateralQRLinkage("PromptPay-PayNow", "TH", QRSchemePromptPay, "764", "SG", QRSchemePayNow, "702")
+func BilateralQRLinkage(name, countryA string, schemeA QRPaymentScheme, currencyA, countryB string, schemeB QRPaymentScheme, currencyB string) []QRLinkage {
+	return []QRLinkage{
+		{Name: name, MerchantCountry: countryA, MerchantScheme: schemeA, AcquirerCountry: countryB, AcquirerScheme: schemeB, DebitCurrency: currencyB},
+		{Name: name, MerchantCountry: countryB, MerchantScheme: schemeB, AcquirerCountry: countryA, AcquirerScheme: schemeA, DebitCurrency: currencyA},
+	}
+}
+
+// Linkages returns a copy of the linkages in the table.
+func (t *LinkageTable) Linkages() []QRLinkage {
+	return append([]QRLinkage(nil), t.linkages...)
+}
+
+// DefaultLinkageTable holds the regional QR linkages supported out of the box.
+var DefaultLinkageTable = NewLinkageTable(concatLinkages(
+	BilateralQRLinkage("PromptPay-PayNow", "TH", QRSchemePromptPay, "764", "SG", QRSchemePayNow, "702"),
+	BilateralQRLinkage("PromptPay-DuitNow", "TH", QRSchemePromptPay, "764", "MY", QRSchemeDuitNow, "458"),
+	BilateralQRLinkage("PromptPay-QRIS", "TH", QRSchemePromptPay, "764", "ID", QRSchemeQRIS, "360"),
+	BilateralQRLinkage("DuitNow-QRIS", "MY", QRSchemeDuitNow, "458", "ID", QRSchemeQRIS, "360"),
+	BilateralQRLinkage("DuitNow-PayNow", "MY", QRSchemeDuitNow, "458", "SG", QRSchemePayNow, "702"),
+)...)
+
+// ClassifyInterop classifies a decoded QR paid from the acquiring country using DefaultLinkageTable.
+func ClassifyInterop(e *EMVData, acquirerCountry string) (*InteropResult, error) {
+	return DefaultLinkageTable.Classify(e, acquirerCountry)
+}
+
+// Classify determines whether paying the QR from the acquiring country is domestic or
+// cross-border, which linkage applies and which currency the consumer is debited in.
+// Every merchant account of the QR is considered, so multi-scheme QRs match any linkage
+// of their schemes. Returns ErrNoQRLinkage if a cross-border payment has no linkage.
+func (t *LinkageTable) Classify(e *EMVData, acquirerCountry string) (*InteropResult, error) {
+	merchantCountry := normalizeCountryCode(e.CountryCode)
+	acquirerCountry = normalizeCountryCode(acquirerCountry)
+	if merchantCountry == "" || acquirerCountry == "" {
+		return nil, ErrInteropMissingCountry
+	}
+
+	result := &InteropResult{
+		MerchantCountry:  merchantCountry,
+		AcquirerCountry:  acquirerCountry,
+		MerchantScheme:   e.QRInfo().PaymentScheme,
+		MerchantCurrency: e.TransactionCurrency,
+	}
+
+	if merchantCountry == acquirerCountry {
+		result.Type = InteropDomestic
+		result.DebitCurrency = e.TransactionCurrency
+		return result, nil
+	}
+
+	schemes := merchantSchemes(e)
+	for i := range t.linkages {
+		linkage := &t.linkages[i]
+		if linkage.MerchantCountry != merchantCountry || linkage.AcquirerCountry != acquirerCountry || !schemes[linkage.MerchantScheme] {
+			continue
+		}
+		applied := *linkage
+		result.Type = InteropCrossBorder
+		result.MerchantScheme = applied.MerchantScheme
+		result.DebitCurrency = applied.DebitCurrency
+		result.Linkage = &applied
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s QR paid from %s", ErrNoQRLinkage, merchantCountry, acquirerCountry)
+}
+
+// merchantSchemes collects the payment schemes of all merchant accounts.
+func merchantSchemes(e *EMVData) map[QRPaymentScheme]bool {
+	schemes := make(map[QRPaymentScheme]bool, len(e.MerchantAccountInfo))
+	for _, account := range e.MerchantAccountInfo {
+		if account != nil {
+			schemes[account.PaymentScheme] = true
+		}
+	}
+	return schemes
+}
+
+// concatLinkages flattens groups of linkages in order.
+func concatLinkages(groups ...[]QRLinkage) []QRLinkage {
+	var linkages []QRLinkage
+	for _, group := range groups {
+		linkages = append(linkages, group...)
+	}
+	return linkages
+}
+
+// normalizeCountryCode trims and upper-cases an ISO 3166-1 alpha-2 country code.
+func normalizeCountryCode(country string) string {
+	return strings.ToUpper(strings.TrimSpace(country))
+}
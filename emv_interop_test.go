@@ -0,0 +1,134 @@
+package xstr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	interopPayNowQR      = "00020101021126330009SG.PAYNOW010120211+651234567853037025802SG6304B1C1"
+	interopDuitNowQR     = "00020101021126260014COM.MY.DUITNOW0104123453034585802MY6304EA0B"
+	interopDuitNowQRISQR = "00020101021126270014COM.MY.DUITNOW01051234527180014ID.CO.QRIS.WWW53034585802MY6304B395"
+	interopUSQR          = "00020101021153038405802US6304F5CD"
+)
+
+func TestClassifyInterop(t *testing.T) {
+	tests := []struct {
+		name            string
+		qrString        string
+		acquirerCountry string
+		expectedType    InteropType
+		expectedLinkage string
+		merchantScheme  QRPaymentScheme
+		debitCurrency   string
+		wantErr         error
+	}{
+		{
+			name:            "domestic PromptPay",
+			qrString:        staticPromptPayQR,
+			acquirerCountry: "TH",
+			expectedType:    InteropDomestic,
+			merchantScheme:  QRSchemePromptPay,
+			debitCurrency:   "764",
+		},
+		{
+			name:            "Thai QR paid in Singapore",
+			qrString:        staticPromptPayQR,
+			acquirerCountry: "sg ",
+			expectedType:    InteropCrossBorder,
+			expectedLinkage: "PromptPay-PayNow",
+			merchantScheme:  QRSchemePromptPay,
+			debitCurrency:   "702",
+		},
+		{
+			name:            "Singapore QR paid in Thailand",
+			qrString:        interopPayNowQR,
+			acquirerCountry: "TH",
+			expectedType:    InteropCrossBorder,
+			expectedLinkage: "PromptPay-PayNow",
+			merchantScheme:  QRSchemePayNow,
+			debitCurrency:   "764",
+		},
+		{
+			name:            "Malaysian QR paid in Thailand",
+			qrString:        interopDuitNowQR,
+			acquirerCountry: "TH",
+			expectedType:    InteropCrossBorder,
+			expectedLinkage: "PromptPay-DuitNow",
+			merchantScheme:  QRSchemeDuitNow,
+			debitCurrency:   "764",
+		},
+		{
+			name:            "multi-scheme Malaysian QR paid in Indonesia",
+			qrString:        interopDuitNowQRISQR,
+			acquirerCountry: "ID",
+			expectedType:    InteropCrossBorder,
+			expectedLinkage: "DuitNow-QRIS",
+			merchantScheme:  QRSchemeDuitNow,
+			debitCurrency:   "360",
+		},
+		{
+			name:            "no linkage",
+			qrString:        interopUSQR,
+			acquirerCountry: "TH",
+			wantErr:         ErrNoQRLinkage,
+		},
+		{
+			name:            "missing acquirer country",
+			qrString:        staticPromptPayQR,
+			acquirerCountry: " ",
+			wantErr:         ErrInteropMissingCountry,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			emvData, err := DecodeEMVQR(tt.qrString)
+			require.NoError(t, err)
+
+			result, err := ClassifyInterop(emvData, tt.acquirerCountry)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedType, result.Type)
+			assert.Equal(t, tt.merchantScheme, result.MerchantScheme)
+			assert.Equal(t, tt.debitCurrency, result.DebitCurrency)
+			assert.Equal(t, emvData.TransactionCurrency, result.MerchantCurrency)
+			if tt.expectedLinkage == "" {
+				assert.Nil(t, result.Linkage)
+			} else {
+				require.NotNil(t, result.Linkage)
+				assert.Equal(t, tt.expectedLinkage, result.Linkage.Name)
+			}
+		})
+	}
+}
+
+func TestLinkageTable_Custom(t *testing.T) {
+	table := NewLinkageTable(QRLinkage{
+		Name:            "PromptPay-Visitor",
+		MerchantCountry: "th",
+		MerchantScheme:  QRSchemePromptPay,
+		AcquirerCountry: "us",
+		DebitCurrency:   "840",
+	})
+
+	emvData, err := DecodeEMVQR(staticPromptPayQR)
+	require.NoError(t, err)
+
+	result, err := table.Classify(emvData, "US")
+	require.NoError(t, err)
+	assert.Equal(t, "840", result.DebitCurrency)
+	assert.Equal(t, "PromptPay-Visitor", result.Linkage.Name)
+
+	_, err = table.Classify(emvData, "SG")
+	assert.ErrorIs(t, err, ErrNoQRLinkage)
+
+	assert.Len(t, table.Linkages(), 1)
+	assert.Len(t, DefaultLinkageTable.Linkages(), 10)
+}
@@ -0,0 +1,333 @@
+package xstr
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Field lengths beyond which scanners commonly truncate or reject a payload.
+const (
+	EMVMaxMerchantNameLength = 25
+	EMVMaxMerchantCityLength = 15
+)
+
+// EMVLintRule identifies the check that produced a lint warning.
+type EMVLintRule string
+
+// EMV lint rule constants
+const (
+	EMVLintMerchantNameTooLong EMVLintRule = "merchant_name_too_long" // Tag 59 longer than 25 characters
+	EMVLintMerchantCityFormat  EMVLintRule = "merchant_city_format"   // Tag 60 not in uppercase ASCII
+	EMVLintMerchantCityTooLong EMVLintRule = "merchant_city_too_long" // Tag 60 longer than 15 characters
+	EMVLintAmountFormat        EMVLintRule = "amount_format"          // Tag 54 not in canonical form, e.g. "10.0" or "010.00"
+	EMVLintInvalidAmount       EMVLintRule = "invalid_amount"         // Tag 54 not a valid amount for the currency (data not produced by the decoder)
+	EMVLintRedundantTip        EMVLintRule = "redundant_tip"          // Tag 56 or 57 not selected by the tag 55 indicator
+	EMVLintMissingTipValue     EMVLintRule = "missing_tip_value"      // Tag 55 selects a fee that is not present
+	EMVLintMissingCountry      EMVLintRule = "missing_country"        // Tag 53 present without tag 58
+	EMVLintFieldOrder          EMVLintRule = "field_order"            // Top-level fields not in ascending tag order
+)
+
+// EMVLintWarning describes a field that decodes but may cause problems with scanners,
+// with the suggested fix when one can be applied automatically.
+type EMVLintWarning struct {
+	Rule       EMVLintRule `json:"rule"`
+	Tag        string      `json:"tag"`
+	Message    string      `json:"message"`
+	Value      string      `json:"value,omitempty"`
+	Suggestion string      `json:"suggestion,omitempty"` // Corrected value; empty with Fixable set means the field is removed
+	Fixable    bool        `json:"fixable"`
+}
+
+// String formats the warning for display, e.g. "59: merchant name is longer than 25 characters".
+func (w EMVLintWarning) String() string {
+	if w.Tag == "" {
+		return w.Message
+	}
+	return w.Tag + ": " + w.Message
+}
+
+// LintEMVQR decodes a payload and reports merchant-facing warnings. Hard spec violations
+// are returned as the decoding error; warnings are ordered by tag.
+//
+// Examples:
+//   - merchant name "THE VERY LONG COFFEE HOUSE LTD" -> merchant_name_too_long, suggestion "THE VERY LONG COFFEE HOUS"
+//   - amount "010.0" in THB -> amount_format, suggestion "10.00"
+func LintEMVQR(qrString string) ([]EMVLintWarning, error) {
+	emvData, err := DecodeEMVQR(qrString)
+	if err != nil {
+		return nil, err
+	}
+	warnings := emvData.Lint()
+	if w, ok := lintFieldOrder(qrString); ok {
+		warnings = append(warnings, w)
+		sortLintWarnings(warnings)
+	}
+	return warnings, nil
+}
+
+// FixEMVQR applies every fixable lint warning and re-encodes the payload in canonical
+// tag order with a fresh CRC. It returns the corrected payload and the warnings that
+// still need manual attention.
+func FixEMVQR(qrString string) (string, []EMVLintWarning, error) {
+	emvData, err := DecodeEMVQR(qrString)
+	if err != nil {
+		return "", nil, err
+	}
+	remaining := emvData.Fix()
+	fixed, err := emvData.Encode()
+	if err != nil {
+		return "", nil, err
+	}
+	return fixed, remaining, nil
+}
+
+// Lint reports merchant-facing warnings for the decoded fields in tag order. Field order
+// cannot be checked on decoded data; use LintEMVQR to include it.
+func (e *EMVData) Lint() []EMVLintWarning {
+	var warnings []EMVLintWarning
+	warnings = append(warnings, e.lintAmount()...)
+	warnings = append(warnings, e.lintTip()...)
+	warnings = append(warnings, e.lintCountry()...)
+	warnings = append(warnings, e.lintMerchantName()...)
+	warnings = append(warnings, e.lintMerchantCity()...)
+	sortLintWarnings(warnings)
+	return warnings
+}
+
+// Fix applies the fixable lint warnings to e and returns the remaining ones,
+// including any fixable warning on a field Fix cannot assign.
+func (e *EMVData) Fix() []EMVLintWarning {
+	var remaining []EMVLintWarning
+	for _, w := range e.Lint() {
+		// Warnings on the same tag build on each other, so the last suggestion wins
+		if !w.Fixable || !e.applyLintFix(w.Tag, w.Suggestion) {
+			remaining = append(remaining, w)
+		}
+	}
+	return remaining
+}
+
+// applyLintFix assigns a suggested value to a linted field, removing it when the value
+// is empty, and reports whether the tag is one Fix can assign.
+func (e *EMVData) applyLintFix(tag, value string) bool {
+	switch tag {
+	case "54":
+		e.TransactionAmount = value
+	case "56":
+		e.ValueOfConvenienceFee = value
+	case "57":
+		if value == "" {
+			delete(e.UnresolvedData, tag)
+		} else {
+			setMapField(&e.UnresolvedData, tag, value)
+		}
+	case "58":
+		e.CountryCode = value
+	case "59":
+		e.MerchantName = value
+	case "60":
+		e.MerchantCity = value
+	default:
+		return false
+	}
+	return true
+}
+
+// lintAmount checks that tag 54 is a valid amount in canonical form for the currency.
+// Whole amounts without decimals, e.g. "10" in THB, are accepted as canonical.
+func (e *EMVData) lintAmount() []EMVLintWarning {
+	amount := e.TransactionAmount
+	currency, ok := lookupCurrency(e.TransactionCurrency)
+	if amount == "" || !ok {
+		return nil
+	}
+
+	minor, err := parseEMVAmountMinorUnits(amount, currency.MinorUnits)
+	if err != nil {
+		return []EMVLintWarning{{
+			Rule:    EMVLintInvalidAmount,
+			Tag:     "54",
+			Message: fmt.Sprintf("amount is not valid for %s; scanners will reject the QR", currency.Alpha),
+			Value:   amount,
+		}}
+	}
+	canonical := formatEMVAmountMinorUnits(minor, currency.MinorUnits)
+	whole, fraction, _ := strings.Cut(canonical, ".")
+	if amount == canonical || (amount == whole && strings.Trim(fraction, "0") == "") {
+		return nil
+	}
+	return []EMVLintWarning{{
+		Rule:       EMVLintAmountFormat,
+		Tag:        "54",
+		Message:    fmt.Sprintf("amount should have no leading zeros and %d decimal places", currency.MinorUnits),
+		Value:      amount,
+		Suggestion: canonical,
+		Fixable:    true,
+	}}
+}
+
+// lintTip checks that the convenience fee fields match the tag 55 indicator:
+// 56 is only used with "02" (fixed fee) and 57 only with "03" (percentage fee).
+func (e *EMVData) lintTip() []EMVLintWarning {
+	var warnings []EMVLintWarning
+	fees := []struct {
+		tag       string
+		value     string
+		indicator string
+	}{
+		{"56", e.ValueOfConvenienceFee, "02"},
+		{"57", e.UnresolvedData["57"], "03"},
+	}
+	for _, fee := range fees {
+		switch {
+		case fee.value != "" && e.TipOrConvenienceIndicator != fee.indicator:
+			warnings = append(warnings, EMVLintWarning{
+				Rule:    EMVLintRedundantTip,
+				Tag:     fee.tag,
+				Message: fmt.Sprintf("convenience fee is ignored unless tag 55 is %q; remove it", fee.indicator),
+				Value:   fee.value,
+				Fixable: true,
+			})
+		case fee.value == "" && e.TipOrConvenienceIndicator == fee.indicator:
+			warnings = append(warnings, EMVLintWarning{
+				Rule:    EMVLintMissingTipValue,
+				Tag:     "55",
+				Message: fmt.Sprintf("tip indicator %q requires tag %s", fee.indicator, fee.tag),
+				Value:   e.TipOrConvenienceIndicator,
+			})
+		}
+	}
+	return warnings
+}
+
+// lintCountry checks that a QR with a currency also carries the country code,
+// suggesting the issuing country of single-country currencies.
+func (e *EMVData) lintCountry() []EMVLintWarning {
+	if e.TransactionCurrency == "" || e.CountryCode != "" {
+		return nil
+	}
+	w := EMVLintWarning{
+		Rule:    EMVLintMissingCountry,
+		Tag:     "58",
+		Message: "country code is required; some scanners reject QRs without it",
+	}
+	if currency, ok := lookupCurrency(e.TransactionCurrency); ok && currency.Country != "" {
+		w.Suggestion = currency.Country
+		w.Fixable = true
+	}
+	return []EMVLintWarning{w}
+}
+
+// lintMerchantName checks the length of tag 59.
+func (e *EMVData) lintMerchantName() []EMVLintWarning {
+	if len(e.MerchantName) <= EMVMaxMerchantNameLength {
+		return nil
+	}
+	return []EMVLintWarning{{
+		Rule:       EMVLintMerchantNameTooLong,
+		Tag:        "59",
+		Message:    fmt.Sprintf("merchant name is longer than %d characters and may be truncated", EMVMaxMerchantNameLength),
+		Value:      e.MerchantName,
+		Suggestion: truncateLintValue(e.MerchantName, EMVMaxMerchantNameLength),
+		Fixable:    true,
+	}}
+}
+
+// lintMerchantCity checks that tag 60 is short uppercase ASCII. The length suggestion
+// builds on the format suggestion so both can be applied in order.
+func (e *EMVData) lintMerchantCity() []EMVLintWarning {
+	city := e.MerchantCity
+	if city == "" {
+		return nil
+	}
+
+	var warnings []EMVLintWarning
+	if normalized := normalizeMerchantCity(city); normalized != city {
+		fixable := isPrintableASCII(normalized)
+		w := EMVLintWarning{
+			Rule:    EMVLintMerchantCityFormat,
+			Tag:     "60",
+			Message: "merchant city should be uppercase without accents",
+			Value:   city,
+			Fixable: fixable,
+		}
+		if fixable {
+			w.Suggestion = normalized
+			city = normalized
+		}
+		warnings = append(warnings, w)
+	}
+	if len(city) > EMVMaxMerchantCityLength {
+		warnings = append(warnings, EMVLintWarning{
+			Rule:       EMVLintMerchantCityTooLong,
+			Tag:        "60",
+			Message:    fmt.Sprintf("merchant city is longer than %d characters and may be truncated", EMVMaxMerchantCityLength),
+			Value:      e.MerchantCity,
+			Suggestion: truncateLintValue(city, EMVMaxMerchantCityLength),
+			Fixable:    true,
+		})
+	}
+	return warnings
+}
+
+// lintFieldOrder reports the first top-level field out of ascending tag order.
+// The CRC is excluded since the decoder already requires it to be last. The warning is
+// not Fixable since no field value changes; FixEMVQR re-encodes in tag order instead.
+func lintFieldOrder(qrString string) (EMVLintWarning, bool) {
+	fields, err := ParseEMVTLV(qrString)
+	if err != nil {
+		return EMVLintWarning{}, false
+	}
+	previous := ""
+	for _, field := range fields {
+		if field.Tag == "63" {
+			continue
+		}
+		if field.Tag < previous {
+			return EMVLintWarning{
+				Rule:    EMVLintFieldOrder,
+				Tag:     field.Tag,
+				Message: fmt.Sprintf("field appears after tag %s; some scanners expect ascending tag order, which FixEMVQR restores", previous),
+			}, true
+		}
+		previous = field.Tag
+	}
+	return EMVLintWarning{}, false
+}
+
+// normalizeMerchantCity uppercases a city name and strips accents,
+// e.g. "Hà Nội" -> "HA NOI". Letters without an ASCII base are kept.
+func normalizeMerchantCity(city string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(city) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
+
+// truncateLintValue shortens a value to at most maxLength bytes on a character
+// boundary, without a trailing space.
+func truncateLintValue(value string, maxLength int) string {
+	if len(value) <= maxLength {
+		return value
+	}
+	for maxLength > 0 && !utf8.RuneStart(value[maxLength]) {
+		maxLength--
+	}
+	return strings.TrimRight(value[:maxLength], " ")
+}
+
+// sortLintWarnings orders warnings by tag, keeping the order of warnings on the same tag.
+func sortLintWarnings(warnings []EMVLintWarning) {
+	sort.SliceStable(warnings, func(i, j int) bool {
+		return warnings[i].Tag < warnings[j].Tag
+	})
+}
@@ -0,0 +1,155 @@
+package xstr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	lintMessyQR      = "00020101021229370016A000000677010111011300668123456785204581253037645930THE VERY LONG COFFEE HOUSE LTD5405010.055020156045.006010Chiang Mai6304A5D9"
+	lintMessyFixedQR = "00020101021229370016A00000067701011101130066812345678520458125303764540510.005502015802TH5925THE VERY LONG COFFEE HOUS6010CHIANG MAI6304AAEA"
+	lintTipQR        = "00020101021229370016A00000067701011101130066812345678520458125303764540310055020256045.00570135802TH5907SOM TAM6021São José dos Campos6304F5F8"
+	lintTipFixedQR   = "00020101021229370016A00000067701011101130066812345678520458125303764540310055020256045.005802TH5907SOM TAM6015SAO JOSE DOS CA6304085A"
+	lintUnfixableQR  = "00020101021229370016A00000067701011101130066812345678530397854041.235502025904CAFE6010Đà Lạt63049ABB"
+)
+
+func TestLintEMVQR(t *testing.T) {
+	tests := []struct {
+		name     string
+		qrString string
+		expected []EMVLintWarning
+	}{
+		{
+			name:     "clean payload",
+			qrString: lintMessyFixedQR,
+		},
+		{
+			name:     "merchant-generated payload",
+			qrString: lintMessyQR,
+			expected: []EMVLintWarning{
+				{Rule: EMVLintAmountFormat, Tag: "54", Message: "amount should have no leading zeros and 2 decimal places", Value: "010.0", Suggestion: "10.00", Fixable: true},
+				{Rule: EMVLintFieldOrder, Tag: "54", Message: "field appears after tag 59; some scanners expect ascending tag order, which FixEMVQR restores"},
+				{Rule: EMVLintRedundantTip, Tag: "56", Message: `convenience fee is ignored unless tag 55 is "02"; remove it`, Value: "5.00", Fixable: true},
+				{Rule: EMVLintMissingCountry, Tag: "58", Message: "country code is required; some scanners reject QRs without it", Suggestion: "TH", Fixable: true},
+				{Rule: EMVLintMerchantNameTooLong, Tag: "59", Message: "merchant name is longer than 25 characters and may be truncated", Value: "THE VERY LONG COFFEE HOUSE LTD", Suggestion: "THE VERY LONG COFFEE HOUS", Fixable: true},
+				{Rule: EMVLintMerchantCityFormat, Tag: "60", Message: "merchant city should be uppercase without accents", Value: "Chiang Mai", Suggestion: "CHIANG MAI", Fixable: true},
+			},
+		},
+		{
+			name:     "percentage fee and accented city",
+			qrString: lintTipQR,
+			expected: []EMVLintWarning{
+				{Rule: EMVLintRedundantTip, Tag: "57", Message: `convenience fee is ignored unless tag 55 is "03"; remove it`, Value: "3", Fixable: true},
+				{Rule: EMVLintMerchantCityFormat, Tag: "60", Message: "merchant city should be uppercase without accents", Value: "São José dos Campos", Suggestion: "SAO JOSE DOS CAMPOS", Fixable: true},
+				{Rule: EMVLintMerchantCityTooLong, Tag: "60", Message: "merchant city is longer than 15 characters and may be truncated", Value: "São José dos Campos", Suggestion: "SAO JOSE DOS CA", Fixable: true},
+			},
+		},
+		{
+			name:     "valid payload out of tag order",
+			qrString: "00020101021129370016A000000677010111011300668123456785802TH5303764540510.0063044ABE",
+			expected: []EMVLintWarning{
+				{Rule: EMVLintFieldOrder, Tag: "53", Message: "field appears after tag 58; some scanners expect ascending tag order, which FixEMVQR restores"},
+			},
+		},
+		{
+			name:     "warnings without automatic fix",
+			qrString: lintUnfixableQR,
+			expected: []EMVLintWarning{
+				{Rule: EMVLintMissingTipValue, Tag: "55", Message: `tip indicator "02" requires tag 56`, Value: "02"},
+				{Rule: EMVLintMissingCountry, Tag: "58", Message: "country code is required; some scanners reject QRs without it"},
+				{Rule: EMVLintMerchantCityFormat, Tag: "60", Message: "merchant city should be uppercase without accents", Value: "Đà Lạt"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings, err := LintEMVQR(tt.qrString)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, warnings)
+		})
+	}
+}
+
+func TestEMVData_Lint_InvalidAmount(t *testing.T) {
+	// Decoded payloads always have valid amounts, so build the data directly
+	emvData := &EMVData{TransactionCurrency: "978", TransactionAmount: "1.234", CountryCode: "DE"}
+	assert.Equal(t, []EMVLintWarning{
+		{Rule: EMVLintInvalidAmount, Tag: "54", Message: "amount is not valid for EUR; scanners will reject the QR", Value: "1.234"},
+	}, emvData.Lint())
+}
+
+func TestLintEMVQR_Invalid(t *testing.T) {
+	_, err := LintEMVQR(lintMessyQR[:len(lintMessyQR)-1] + "0")
+	assert.ErrorIs(t, err, ErrEMVCRCMismatch)
+
+	_, _, err = FixEMVQR("0002")
+	assert.Error(t, err)
+}
+
+func TestFixEMVQR(t *testing.T) {
+	tests := []struct {
+		name      string
+		qrString  string
+		expected  string
+		remaining []EMVLintRule
+	}{
+		{name: "clean payload is unchanged", qrString: lintMessyFixedQR, expected: lintMessyFixedQR},
+		{name: "all warnings fixed", qrString: lintMessyQR, expected: lintMessyFixedQR},
+		{name: "suggestions on the same tag are combined", qrString: lintTipQR, expected: lintTipFixedQR},
+		{
+			name:      "unfixable warnings remain",
+			qrString:  lintUnfixableQR,
+			expected:  lintUnfixableQR,
+			remaining: []EMVLintRule{EMVLintMissingTipValue, EMVLintMissingCountry, EMVLintMerchantCityFormat},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixed, remaining, err := FixEMVQR(tt.qrString)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, fixed)
+
+			var rules []EMVLintRule
+			for _, w := range remaining {
+				assert.False(t, w.Fixable)
+				rules = append(rules, w.Rule)
+			}
+			assert.Equal(t, tt.remaining, rules)
+
+			warnings, err := LintEMVQR(fixed)
+			require.NoError(t, err)
+			assert.Equal(t, remaining, warnings)
+		})
+	}
+}
+
+func TestEMVData_applyLintFix(t *testing.T) {
+	emvData := &EMVData{UnresolvedData: map[string]string{"57": "3"}}
+	for _, tag := range []string{"54", "56", "58", "59", "60"} {
+		assert.True(t, emvData.applyLintFix(tag, "X"+tag))
+	}
+	assert.True(t, emvData.applyLintFix("57", ""))
+	assert.Equal(t, &EMVData{
+		TransactionAmount:     "X54",
+		ValueOfConvenienceFee: "X56",
+		CountryCode:           "X58",
+		MerchantName:          "X59",
+		MerchantCity:          "X60",
+		UnresolvedData:        map[string]string{},
+	}, emvData)
+
+	// Fields outside the lint rules are left untouched and reported as not applied
+	assert.False(t, emvData.applyLintFix("29", "X29"))
+	assert.False(t, emvData.applyLintFix("", ""))
+	assert.Nil(t, emvData.MerchantAccountInfo)
+}
+
+func TestEMVLintWarning_String(t *testing.T) {
+	w := EMVLintWarning{Tag: "59", Message: "merchant name is longer than 25 characters and may be truncated"}
+	assert.Equal(t, "59: merchant name is longer than 25 characters and may be truncated", w.String())
+	assert.Equal(t, "no tag", EMVLintWarning{Message: "no tag"}.String())
+}
@@ -0,0 +1,142 @@
+package xstr
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// emvDataJSON has the fields of EMVData without its marshalling methods.
+type emvDataJSON EMVData
+
+// MarshalText encodes e as its canonical QR payload string (see Encode).
+// It does not reproduce a decoded payload byte for byte: fields are written in
+// ascending tag order and the CRC is recomputed, so a payload scanned in another
+// order comes back rewritten. Use DecodeEMVRecord to keep the original payload.
+func (e *EMVData) MarshalText() ([]byte, error) {
+	payload, err := e.Encode()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(payload), nil
+}
+
+// UnmarshalText decodes a QR payload string into e using DecodeEMVQR.
+func (e *EMVData) UnmarshalText(text []byte) error {
+	decoded, err := DecodeEMVQR(string(text))
+	if err != nil {
+		return err
+	}
+	*e = *decoded
+	return nil
+}
+
+// MarshalJSON encodes e as a JSON object of its decoded fields.
+// Use NewEMVRecord to also embed the raw payload and QRInfo.
+func (e *EMVData) MarshalJSON() ([]byte, error) {
+	return json.Marshal((*emvDataJSON)(e))
+}
+
+// UnmarshalJSON accepts either the JSON object produced by MarshalJSON
+// or a JSON string holding the raw QR payload. JSON null leaves e unchanged.
+func (e *EMVData) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var payload string
+	if err := json.Unmarshal(data, &payload); err == nil {
+		return e.UnmarshalText([]byte(payload))
+	}
+	return json.Unmarshal(data, (*emvDataJSON)(e))
+}
+
+// Value implements driver.Valuer, storing EMV data as its canonical QR payload string (see Encode).
+// Like MarshalText it rewrites a payload scanned in non-canonical order, changing its tag
+// order and CRC. Columns that must keep scanned payloads for audit or reconciliation
+// should store the original string, e.g. EMVRecord.Payload from DecodeEMVRecord.
+func (e *EMVData) Value() (driver.Value, error) {
+	payload, err := e.Encode()
+	if err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// Scan implements sql.Scanner, decoding a QR payload column.
+// A NULL column resets e to its zero value.
+func (e *EMVData) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*e = EMVData{}
+		return nil
+	case string:
+		return e.UnmarshalText([]byte(v))
+	case []byte:
+		return e.UnmarshalText(v)
+	default:
+		return fmt.Errorf("cannot scan %T into EMVData", src)
+	}
+}
+
+// EMVRecord is a JSON envelope for decoded EMV data that can carry
+// the raw QR payload and the computed QRInfo alongside the fields.
+type EMVRecord struct {
+	Payload string   `json:"payload,omitempty"`
+	Data    *EMVData `json:"data"`
+	QRInfo  *QRInfo  `json:"qr_info,omitempty"`
+}
+
+// NewEMVRecord builds an EMVRecord for e, embedding the canonical payload (see Encode)
+// and the computed QRInfo when requested.
+func NewEMVRecord(e *EMVData, withPayload, withQRInfo bool) (*EMVRecord, error) {
+	record := &EMVRecord{Data: e}
+	if withPayload {
+		payload, err := e.Encode()
+		if err != nil {
+			return nil, err
+		}
+		record.Payload = payload
+	}
+	if withQRInfo {
+		info := e.QRInfo()
+		record.QRInfo = &info
+	}
+	return record, nil
+}
+
+// DecodeEMVRecord decodes a QR payload with DecodeEMVQR into an EMVRecord that keeps the
+// payload byte for byte, so stored payloads are never rewritten in canonical order.
+// The computed QRInfo is embedded when requested.
+func DecodeEMVRecord(payload string, withQRInfo bool) (*EMVRecord, error) {
+	emvData, err := DecodeEMVQR(payload)
+	if err != nil {
+		return nil, err
+	}
+	record, err := NewEMVRecord(emvData, false, withQRInfo)
+	if err != nil {
+		return nil, err
+	}
+	record.Payload = payload
+	return record, nil
+}
+
+// Value implements driver.Valuer, storing QRInfo as a JSON document.
+func (i QRInfo) Value() (driver.Value, error) {
+	return json.Marshal(i)
+}
+
+// Scan implements sql.Scanner, decoding a JSON document column.
+// A NULL column resets i to its zero value.
+func (i *QRInfo) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*i = QRInfo{}
+		return nil
+	case string:
+		return json.Unmarshal([]byte(v), i)
+	case []byte:
+		return json.Unmarshal(v, i)
+	default:
+		return fmt.Errorf("cannot scan %T into QRInfo", src)
+	}
+}
@@ -0,0 +1,169 @@
+package xstr
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const marshalQR = "00020101021130750016A00000067701011201150107537000882050219ZY010556UP8013305E80309MDMBEN38J53037645406900.045802TH622407200000yJMlWBD1ltXF6zJf6304858E"
+
+var (
+	_ sql.Scanner   = (*EMVData)(nil)
+	_ driver.Valuer = (*EMVData)(nil)
+	_ sql.Scanner   = (*QRInfo)(nil)
+	_ driver.Valuer = QRInfo{}
+)
+
+func TestEMVData_TextRoundTrip(t *testing.T) {
+	var emvData EMVData
+	require.NoError(t, emvData.UnmarshalText([]byte(marshalQR)))
+	assert.Equal(t, "900.04", emvData.TransactionAmount)
+
+	text, err := emvData.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, marshalQR, string(text))
+
+	assert.Error(t, emvData.UnmarshalText([]byte("010201630441C6")))
+}
+
+func TestEMVData_JSON(t *testing.T) {
+	emvData, err := DecodeEMVQR(marshalQR)
+	require.NoError(t, err)
+
+	// Default JSON keeps the object form with struct tags
+	data, err := json.Marshal(emvData)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"transaction_amount":"900.04"`)
+
+	var fromObject EMVData
+	require.NoError(t, json.Unmarshal(data, &fromObject))
+	assert.Equal(t, *emvData, fromObject)
+
+	// A JSON string is decoded as a raw payload
+	var fromString EMVData
+	require.NoError(t, json.Unmarshal([]byte(`"`+marshalQR+`"`), &fromString))
+	assert.Equal(t, *emvData, fromString)
+
+	assert.Error(t, json.Unmarshal([]byte(`"010201630441C6"`), &fromString))
+
+	// JSON null is a no-op, as for other json.Unmarshaler implementations
+	var wrapper struct {
+		QR EMVData `json:"qr"`
+	}
+	wrapper.QR.TransactionAmount = "kept"
+	require.NoError(t, json.Unmarshal([]byte(`{"qr":null}`), &wrapper))
+	assert.Equal(t, "kept", wrapper.QR.TransactionAmount)
+}
+
+func TestNewEMVRecord(t *testing.T) {
+	emvData, err := DecodeEMVQR(marshalQR)
+	require.NoError(t, err)
+
+	record, err := NewEMVRecord(emvData, true, true)
+	require.NoError(t, err)
+	assert.Equal(t, marshalQR, record.Payload)
+	require.NotNil(t, record.QRInfo)
+	assert.Equal(t, QRSchemePromptPay, record.QRInfo.PaymentScheme)
+
+	data, err := json.Marshal(record)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"payload":"`+marshalQR+`"`)
+	assert.Contains(t, string(data), `"qr_info":{`)
+	assert.Contains(t, string(data), `"data":{`)
+
+	var decoded EMVRecord
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, *emvData, *decoded.Data)
+
+	bare, err := NewEMVRecord(emvData, false, false)
+	require.NoError(t, err)
+	data, err = json.Marshal(bare)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), `"payload"`)
+	assert.NotContains(t, string(data), `"qr_info"`)
+}
+
+func TestEMVData_SQL(t *testing.T) {
+	emvData, err := DecodeEMVQR(marshalQR)
+	require.NoError(t, err)
+
+	value, err := emvData.Value()
+	require.NoError(t, err)
+	assert.Equal(t, marshalQR, value)
+
+	tests := []struct {
+		name    string
+		src     any
+		wantErr bool
+		want    string
+	}{
+		{name: "string column", src: marshalQR, want: "900.04"},
+		{name: "bytes column", src: []byte(marshalQR), want: "900.04"},
+		{name: "null column", src: nil, want: ""},
+		{name: "invalid payload", src: "010201630441C6", wantErr: true},
+		{name: "unsupported type", src: 42, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scanned := EMVData{TransactionAmount: "stale"}
+			err := scanned.Scan(tt.src)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, scanned.TransactionAmount)
+		})
+	}
+}
+
+func TestDecodeEMVRecord(t *testing.T) {
+	// Sub-fields and top-level tags out of canonical order, as printed by some issuers
+	const outOfOrderQR = "00020101021129370016A000000677010111011300668123456785802TH5303764540510.0063044ABE"
+	canonicalQR := AppendEMVCRC("00020101021129370016A000000677010111011300668123456785303764540510.005802TH")
+
+	emvData, err := DecodeEMVQR(outOfOrderQR)
+	require.NoError(t, err)
+
+	// Marshalling always emits the canonical encoding
+	text, err := emvData.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, canonicalQR, string(text))
+	value, err := emvData.Value()
+	require.NoError(t, err)
+	assert.Equal(t, canonicalQR, value)
+
+	// The record keeps the scanned payload byte for byte
+	record, err := DecodeEMVRecord(outOfOrderQR, true)
+	require.NoError(t, err)
+	assert.Equal(t, outOfOrderQR, record.Payload)
+	assert.Equal(t, emvData, record.Data)
+	require.NotNil(t, record.QRInfo)
+	assert.Equal(t, "0066812345678", record.QRInfo.MerchantID)
+
+	_, err = DecodeEMVRecord("010201630441C6", false)
+	assert.ErrorIs(t, err, ErrEMVCRCMismatch)
+}
+
+func TestQRInfo_SQL(t *testing.T) {
+	emvData, err := DecodeEMVQR(marshalQR)
+	require.NoError(t, err)
+	info := emvData.QRInfo()
+
+	value, err := info.Value()
+	require.NoError(t, err)
+
+	var scanned QRInfo
+	require.NoError(t, scanned.Scan(value))
+	assert.Equal(t, info, scanned)
+
+	require.NoError(t, scanned.Scan(nil))
+	assert.Equal(t, QRInfo{}, scanned)
+	assert.Error(t, scanned.Scan(1.5))
+}
@@ -0,0 +1,130 @@
+package xstr
+
+import (
+	"strings"
+)
+
+// MaskEMVQR masks sensitive values inside an EMV payload for safe logging.
+// Masking is done in place so every tag and length is preserved and the result still
+// parses with ParseEMVTLV:
+//   - merchant account identifiers (tags 02-51, except the GUI in sub-field 00) use MaskSensitive,
+//     or MaskPhone for PromptPay mobile proxies
+//   - proprietary sub-fields of unreserved templates (tags 80-99) use MaskSensitive
+//   - bill numbers, mobile numbers, store labels, loyalty numbers, references, customer
+//     labels, terminal labels and merchant tax IDs in tag 62 use MaskSensitive, or MaskPhone
+//     for mobile numbers
+//   - payment system specific templates in tag 62 (sub-tags 50-99) are masked like
+//     merchant accounts, keeping their GUI in sub-field 00
+//   - the CRC value is redacted, so the masked payload never passes CRC verification
+//
+// Masked characters are replaced with '*' so values keep their original length.
+//
+// Examples:
+//   - MaskEMVQR("...29370016A000000677010111011300668123456785802TH...63044ABE") ->
+//     "...29370016A0000006770101110113*********56785802TH...6304****"
+func MaskEMVQR(qrString string) (string, error) {
+	if _, err := ParseEMVTLV(qrString); err != nil {
+		return "", err
+	}
+
+	masked := []byte(qrString)
+	position := 0
+	for field, err := range EMVTLVSeq(qrString) {
+		if err != nil {
+			// Trailing data accepted by ParseEMVTLV is kept as is
+			break
+		}
+		value := masked[position+4 : position+4+field.Length]
+		switch {
+		case field.Tag == "63":
+			copy(value, strings.Repeat("*", field.Length))
+		case field.Tag >= "02" && field.Tag <= "25":
+			maskEMVValue(value, field.Value, MaskSensitive)
+		case field.Tag >= "26" && field.Tag <= "51", field.Tag >= "80" && field.Tag <= "99":
+			scheme := QRSchemeUnknown
+			if subFields, err := parseSubFields(field.Value); err == nil {
+				scheme = mapScheme(subFields["00"])
+			}
+			maskEMVSubFields(value, field.Value, func(subTag string) func(string) string {
+				switch {
+				case subTag == "00":
+					return nil
+				case field.Tag == "29" && scheme == QRSchemePromptPay && mapPromptPayProxyType(subTag) == PromptPayProxyMobile:
+					return MaskPhone
+				default:
+					return MaskSensitive
+				}
+			})
+		case field.Tag == "62":
+			maskEMVAdditionalData(value, field.Value)
+		}
+		position += 4 + field.Length
+	}
+
+	return string(masked), nil
+}
+
+// maskEMVAdditionalData masks the additional data template (tag 62) in place.
+func maskEMVAdditionalData(dst []byte, value string) {
+	maskEMVSubFields(dst, value, func(subTag string) func(string) string {
+		switch subTag {
+		case "02":
+			return MaskPhone
+		case "01", "03", "04", "05", "06", "07", "10":
+			return MaskSensitive
+		default:
+			return nil
+		}
+	})
+	if _, err := parseSubFields(value); err != nil {
+		// Already masked as a whole
+		return
+	}
+
+	// Payment system specific templates are GUI-based like merchant accounts
+	position := 0
+	for subField, err := range EMVTLVSeq(value) {
+		if err != nil {
+			break
+		}
+		if subField.Tag >= "50" && subField.Tag <= "99" {
+			maskEMVSubFields(dst[position+4:position+4+subField.Length], subField.Value, func(subTag string) func(string) string {
+				if subTag == "00" {
+					return nil
+				}
+				return MaskSensitive
+			})
+		}
+		position += 4 + subField.Length
+	}
+}
+
+// maskEMVSubFields masks the sub-fields of a template value in place using the mask
+// function chosen per sub-tag. A template that does not parse is masked as a whole.
+func maskEMVSubFields(dst []byte, value string, maskFor func(subTag string) func(string) string) {
+	if _, err := parseSubFields(value); err != nil {
+		maskEMVValue(dst, value, MaskSensitive)
+		return
+	}
+
+	position := 0
+	for subField, err := range EMVTLVSeq(value) {
+		if err != nil {
+			// Trailing data accepted by parseSubFields is kept as is
+			break
+		}
+		if mask := maskFor(subField.Tag); mask != nil {
+			maskEMVValue(dst[position+4:position+4+subField.Length], subField.Value, mask)
+		}
+		position += 4 + subField.Length
+	}
+}
+
+// maskEMVValue writes the masked value into dst, widening or narrowing the "****"
+// run produced by mask so the result has the same length as value.
+func maskEMVValue(dst []byte, value string, mask func(string) string) {
+	masked := mask(value)
+	visible := len(masked) - len("****")
+	masked = strings.Replace(masked, "****", strings.Repeat("*", len(value)-visible), 1)
+	copy(dst, masked)
+}
@@ -0,0 +1,117 @@
+package xstr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaskEMVQR(t *testing.T) {
+	tests := []struct {
+		name     string
+		qrString string
+		expected string
+	}{
+		{
+			name:     "promptpay mobile",
+			qrString: "00020101021129370016A000000677010111011300668123456785802TH5303764540510.0063044ABE",
+			expected: "00020101021129370016A0000006770101110113*********56785802TH5303764540510.006304****",
+		},
+		{
+			name:     "promptpay national ID",
+			qrString: "00020101021129370016A000000677010111021311017002034505802TH53037646304D605",
+			expected: "00020101021129370016A00000067701011102131101*****34505802TH53037646304****",
+		},
+		{
+			name:     "bill payment with additional data",
+			qrString: "00020101021230590016A000000677010112011501075370008820502061234560306ABCDEF530376454031005802TH62210105INV010708TERM00016304C43B",
+			expected: "00020101021230590016A00000067701011201150107*******82050206****560306****EF530376454031005802TH62210105***010708******016304****",
+		},
+		{
+			name:     "additional data mobile number",
+			qrString: "00020101021162170213+660812345678",
+			expected: "00020101021162170213+66******5678",
+		},
+		{
+			name:     "additional data store label and payment system template",
+			qrString: "000201010211624003081234567850240014ID.CO.QRIS.WWW0102AB",
+			expected: "00020101021162400308******7850240014ID.CO.QRIS.WWW0102**",
+		},
+		{
+			name:     "primitive card network identifier",
+			qrString: "000201010211041641111111111111115802TH",
+			expected: "00020101021104164111********11115802TH",
+		},
+		{
+			name:     "unreserved template",
+			qrString: "00020101021180350014ID.CO.QRIS.WWW0106ID10200203UMI",
+			expected: "00020101021180350014ID.CO.QRIS.WWW0106****200203***",
+		},
+		{
+			name:     "malformed template is masked as a whole",
+			qrString: "0002010102112606ABCDEF",
+			expected: "0002010102112606****EF",
+		},
+		{
+			name:     "sub-field trailing data is preserved",
+			qrString: "0002010102112606000101XY",
+			expected: "0002010102112606000101XY",
+		},
+		{
+			name:     "trailing data is preserved",
+			qrString: "000201010211XY",
+			expected: "000201010211XY",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := MaskEMVQR(tt.qrString)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+
+			// Structure and lengths are preserved
+			original, err := ParseEMVTLV(tt.qrString)
+			require.NoError(t, err)
+			masked, err := ParseEMVTLV(result)
+			require.NoError(t, err)
+			require.Len(t, masked, len(original))
+			for i := range original {
+				assert.Equal(t, original[i].Tag, masked[i].Tag)
+				assert.Equal(t, original[i].Length, masked[i].Length)
+			}
+		})
+	}
+}
+
+func TestMaskEMVQR_CRCRedacted(t *testing.T) {
+	result, err := MaskEMVQR("00020101021129370016A000000677010111011300668123456785802TH5303764540510.0063044ABE")
+	require.NoError(t, err)
+	assert.ErrorIs(t, VerifyEMVCRC(result), ErrEMVCRCMismatch)
+}
+
+func TestMaskEMVQR_Error(t *testing.T) {
+	_, err := MaskEMVQR("0005AB")
+	assert.ErrorIs(t, err, ErrEMVInvalidData)
+}
+
+func FuzzMaskEMVQR(f *testing.F) {
+	f.Add("00020101021129370016A000000677010111011300668123456785802TH5303764540510.0063044ABE")
+	f.Add("00020101021230590016A000000677010112011501075370008820502061234560306ABCDEF530376454031005802TH62210105INV010708TERM00016304C43B")
+	f.Add("0002010102112606ABCDEF")
+	f.Add("000201010211XY")
+
+	f.Fuzz(func(t *testing.T, qrString string) {
+		result, err := MaskEMVQR(qrString)
+		if err != nil {
+			return
+		}
+		if len(result) != len(qrString) {
+			t.Fatalf("masked length %d differs from input length %d", len(result), len(qrString))
+		}
+		if _, err := ParseEMVTLV(result); err != nil {
+			t.Fatalf("masked payload %q does not parse: %v", result, err)
+		}
+	})
+}
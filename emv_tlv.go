@@ -0,0 +1,84 @@
+package xstr
+
+import (
+	"fmt"
+	"iter"
+)
+
+// TLVError describes a malformed TLV field found while iterating a payload.
+type TLVError struct {
+	Err      error  // ErrEMVInvalidLength, ErrEMVInvalidData or ErrEMVTrailingData
+	Position int    // Offset of the malformed field's tag
+	Tag      string // Tag of the malformed field (empty for trailing data)
+	Length   string // Raw 2-character length field (empty for trailing data)
+}
+
+// Error formats the error with the same wording used by DecodeEMVQR and ParseEMVTLV.
+func (e *TLVError) Error() string {
+	switch e.Err {
+	case ErrEMVInvalidLength:
+		return fmt.Sprintf("%v at position %d: %s", e.Err, e.Position+2, e.Length)
+	case ErrEMVInvalidData:
+		return fmt.Sprintf("%v at tag %s", e.Err, e.Tag)
+	default:
+		return fmt.Sprintf("%v at position %d", e.Err, e.Position)
+	}
+}
+
+// Unwrap returns the underlying sentinel error.
+func (e *TLVError) Unwrap() error {
+	return e.Err
+}
+
+// EMVTLVSeq returns an iterator over the TLV fields of an EMV payload or template value.
+// Yielded values are views into data (Value is a substring), so iteration does not allocate.
+// A malformed field yields a *TLVError and ends the iteration; leftover data shorter than
+// a tag and length header yields a *TLVError wrapping ErrEMVTrailingData.
+//
+// Example:
+//
+//	for field, err := range EMVTLVSeq(qrString) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(field.Tag, field.Value)
+//	}
+func EMVTLVSeq(data string) iter.Seq2[EMVDataValue, error] {
+	return func(yield func(EMVDataValue, error) bool) {
+		position := 0
+		for position < len(data) {
+			if position+4 > len(data) {
+				yield(EMVDataValue{}, &TLVError{Err: ErrEMVTrailingData, Position: position})
+				return
+			}
+
+			// Parse tag (2 digits) and length (2 digits)
+			tag := data[position : position+2]
+			lengthStr := data[position+2 : position+4]
+			length, ok := parseTLVLength(lengthStr)
+			if !ok {
+				yield(EMVDataValue{Tag: tag}, &TLVError{Err: ErrEMVInvalidLength, Position: position, Tag: tag, Length: lengthStr})
+				return
+			}
+
+			start := position + 4
+			if start+length > len(data) {
+				yield(EMVDataValue{Tag: tag}, &TLVError{Err: ErrEMVInvalidData, Position: position, Tag: tag, Length: lengthStr})
+				return
+			}
+
+			if !yield(EMVDataValue{Tag: tag, Length: length, Value: data[start : start+length]}, nil) {
+				return
+			}
+			position = start + length
+		}
+	}
+}
+
+// parseTLVLength parses a 2-digit decimal length field.
+func parseTLVLength(s string) (int, bool) {
+	if len(s) != 2 || s[0] < '0' || s[0] > '9' || s[1] < '0' || s[1] > '9' {
+		return 0, false
+	}
+	return int(s[0]-'0')*10 + int(s[1]-'0'), true
+}
@@ -0,0 +1,180 @@
+package xstr
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const benchEMVQR = "00020101021130750016A00000067701011201150107537000882050219ZY010556UP8013305E80309MDMBEN38J53037645406900.045802TH622407200000yJMlWBD1ltXF6zJf6304858E"
+
+func TestEMVTLVSeq(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		wantTags []string
+		wantErr  error
+		errMsg   string
+	}{
+		{
+			name:     "valid payload",
+			data:     benchEMVQR,
+			wantTags: []string{"00", "01", "30", "53", "54", "58", "62", "63"},
+		},
+		{
+			name:     "empty data",
+			data:     "",
+			wantTags: nil,
+		},
+		{
+			name:     "zero length value",
+			data:     "0000",
+			wantTags: []string{"00"},
+		},
+		{
+			name:     "invalid length",
+			data:     "00020101XX",
+			wantTags: []string{"00"},
+			wantErr:  ErrEMVInvalidLength,
+			errMsg:   "invalid length at position 8: XX",
+		},
+		{
+			name:    "negative length",
+			data:    "00-1",
+			wantErr: ErrEMVInvalidLength,
+			errMsg:  "invalid length at position 2: -1",
+		},
+		{
+			name:    "data length exceeds input",
+			data:    "0010abc",
+			wantErr: ErrEMVInvalidData,
+			errMsg:  "invalid data length at tag 00",
+		},
+		{
+			name:     "trailing data",
+			data:     "000201010",
+			wantTags: []string{"00"},
+			wantErr:  ErrEMVTrailingData,
+			errMsg:   "trailing data at position 6",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tags []string
+			var gotErr error
+			for field, err := range EMVTLVSeq(tt.data) {
+				if err != nil {
+					gotErr = err
+					break
+				}
+				assert.Equal(t, field.Length, len(field.Value))
+				tags = append(tags, field.Tag)
+			}
+
+			assert.Equal(t, tt.wantTags, tags)
+			if tt.wantErr != nil {
+				require.Error(t, gotErr)
+				assert.ErrorIs(t, gotErr, tt.wantErr)
+				assert.Contains(t, gotErr.Error(), tt.errMsg)
+			} else {
+				assert.NoError(t, gotErr)
+			}
+		})
+	}
+}
+
+func TestEMVTLVSeq_EarlyBreak(t *testing.T) {
+	count := 0
+	for range EMVTLVSeq(benchEMVQR) {
+		count++
+		if count == 2 {
+			break
+		}
+	}
+	assert.Equal(t, 2, count)
+}
+
+func TestEMVTLVSeq_ZeroAllocations(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		for field, err := range EMVTLVSeq(benchEMVQR) {
+			if err != nil || field.Tag == "" {
+				t.Fatal("unexpected field")
+			}
+		}
+	})
+	assert.Zero(t, allocs)
+}
+
+// BenchmarkEMVTLV compares the slice-building parser used before EMVTLVSeq
+// ("append") with ParseEMVTLV and the iterator, e.g. with
+// go test -run '^$' -bench 'EMVTLV$' -benchmem.
+func BenchmarkEMVTLV(b *testing.B) {
+	b.Run("append", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := parseEMVTLVAppend(benchEMVQR); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("ParseEMVTLV", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := ParseEMVTLV(benchEMVQR); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("EMVTLVSeq", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			for _, err := range EMVTLVSeq(benchEMVQR) {
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+}
+
+// parseEMVTLVAppend is the parser ParseEMVTLV used before EMVTLVSeq, growing the
+// slice field by field. It is kept as the baseline of BenchmarkEMVTLV.
+func parseEMVTLVAppend(qrString string) ([]EMVDataValue, error) {
+	var tlvData []EMVDataValue
+	position := 0
+	for position+4 <= len(qrString) {
+		tag := qrString[position : position+2]
+		length, err := strconv.Atoi(qrString[position+2 : position+4])
+		if err != nil {
+			return nil, err
+		}
+		position += 4
+		if position+length > len(qrString) {
+			return nil, ErrEMVInvalidData
+		}
+		tlvData = append(tlvData, EMVDataValue{Tag: tag, Length: length, Value: qrString[position : position+length]})
+		position += length
+	}
+	return tlvData, nil
+}
+
+func BenchmarkDecodeEMVQR(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := DecodeEMVQR(benchEMVQR); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkVerifyEMVCRC(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		if err := VerifyEMVCRC(benchEMVQR); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
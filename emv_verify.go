@@ -0,0 +1,241 @@
+package xstr
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrMerchantNotFound is returned by a MerchantRegistry when no merchant is registered.
+var ErrMerchantNotFound = errors.New("merchant not found")
+
+// Merchant verification field names reported in mismatches.
+const (
+	MerchantFieldRegistration = "registration"
+	MerchantFieldScheme       = "scheme"
+	MerchantFieldMerchantID   = "merchant_id"
+	MerchantFieldAccount      = "account"
+	MerchantFieldName         = "name"
+	MerchantFieldCity         = "city"
+	MerchantFieldMCC          = "mcc"
+)
+
+// merchantFieldRisk is the risk added by a mismatch of each field.
+var merchantFieldRisk = map[string]int{
+	MerchantFieldRegistration: 100,
+	MerchantFieldScheme:       100,
+	MerchantFieldMerchantID:   100,
+	MerchantFieldAccount:      100,
+	MerchantFieldName:         40,
+	MerchantFieldCity:         20,
+	MerchantFieldMCC:          20,
+}
+
+// ExpectedMerchant is the registered identity of a merchant QR.
+// AccountIDs lists the merchant's other accounts of multi-scheme QRs, keyed by tag
+// (e.g. "02" for a Visa merchant ID) or by payment scheme for tags 26-51 (e.g. "QRIS").
+// Empty Name, City and MCC are not checked.
+type ExpectedMerchant struct {
+	Scheme     QRPaymentScheme   `json:"scheme"`
+	MerchantID string            `json:"merchant_id"` // Merchant or biller ID, or the PromptPay proxy for tag 29
+	AccountIDs map[string]string `json:"account_ids,omitempty"`
+	Name       string            `json:"name"`
+	City       string            `json:"city"`
+	MCC        string            `json:"mcc"`
+}
+
+// MerchantMismatch describes a field that differs from the registered merchant.
+type MerchantMismatch struct {
+	Field    string `json:"field"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+// MerchantVerification is the result of verifying a QR against the registry.
+type MerchantVerification struct {
+	Registered bool               `json:"registered"`
+	Merchant   *ExpectedMerchant  `json:"merchant,omitempty"` // Registered merchant, nil if not registered
+	Mismatches []MerchantMismatch `json:"mismatches,omitempty"`
+	RiskScore  int                `json:"risk_score"` // 0 (matches) to 100 (unregistered or wrong account)
+}
+
+// OK reports whether the QR matches a registered merchant without mismatches.
+func (v *MerchantVerification) OK() bool {
+	return v.Registered && len(v.Mismatches) == 0
+}
+
+// MerchantRegistry looks up registered merchants by scheme and merchant ID.
+// Implementations return ErrMerchantNotFound when no merchant is registered.
+type MerchantRegistry interface {
+	LookupMerchant(ctx context.Context, scheme QRPaymentScheme, merchantID string) (*ExpectedMerchant, error)
+}
+
+// InMemoryMerchantRegistry is a MerchantRegistry backed by a map.
+// It is safe for concurrent use.
+type InMemoryMerchantRegistry struct {
+	mu        sync.RWMutex
+	merchants map[string]ExpectedMerchant
+}
+
+// NewInMemoryMerchantRegistry creates a registry holding the given merchants.
+func NewInMemoryMerchantRegistry(merchants ...ExpectedMerchant) *InMemoryMerchantRegistry {
+	r := &InMemoryMerchantRegistry{merchants: make(map[string]ExpectedMerchant, len(merchants))}
+	for _, merchant := range merchants {
+		r.Add(merchant)
+	}
+	return r
+}
+
+// Add registers or replaces a merchant.
+func (r *InMemoryMerchantRegistry) Add(merchant ExpectedMerchant) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.merchants[merchantRegistryKey(merchant.Scheme, merchant.MerchantID)] = merchant
+}
+
+// LookupMerchant implements MerchantRegistry.
+func (r *InMemoryMerchantRegistry) LookupMerchant(_ context.Context, scheme QRPaymentScheme, merchantID string) (*ExpectedMerchant, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	merchant, exists := r.merchants[merchantRegistryKey(scheme, merchantID)]
+	if !exists {
+		return nil, ErrMerchantNotFound
+	}
+	return &merchant, nil
+}
+
+// MerchantVerifier checks decoded QRs against a merchant registry to detect
+// stickers replaced or tampered with by fraudsters.
+type MerchantVerifier struct {
+	registry MerchantRegistry
+}
+
+// NewMerchantVerifier creates a verifier backed by the registry.
+func NewMerchantVerifier(registry MerchantRegistry) *MerchantVerifier {
+	return &MerchantVerifier{registry: registry}
+}
+
+// Verify looks up every merchant account of the QR in ascending tag order and compares
+// the first registered merchant with the QR. A QR without any registered account is
+// reported as unregistered with the maximum risk score. Registry errors other than
+// ErrMerchantNotFound are returned.
+func (v *MerchantVerifier) Verify(ctx context.Context, e *EMVData) (*MerchantVerification, error) {
+	for _, tag := range sortedAccountTags(e) {
+		account := e.MerchantAccountInfo[tag]
+		merchant, err := v.registry.LookupMerchant(ctx, account.PaymentScheme, merchantAccountID(tag, account))
+		if errors.Is(err, ErrMerchantNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return VerifyMerchant(e, merchant), nil
+	}
+
+	return &MerchantVerification{
+		Mismatches: []MerchantMismatch{{Field: MerchantFieldRegistration, Expected: "registered merchant"}},
+		RiskScore:  merchantFieldRisk[MerchantFieldRegistration],
+	}, nil
+}
+
+// VerifyMerchant compares a QR with a known expected merchant, e.g. the merchant
+// registered for the shop where the sticker was scanned. Each merchant account
+// (tags 02-51) is compared with the ID registered for its tag or scheme: a different
+// ID is reported as a merchant_id mismatch and an account the merchant has not
+// registered as an account mismatch. Names and cities are compared case-insensitively
+// after RemoveDuplicateSpaces, so spacing differences are not reported.
+func VerifyMerchant(e *EMVData, expected *ExpectedMerchant) *MerchantVerification {
+	result := &MerchantVerification{Registered: true, Merchant: expected}
+	mismatch := func(field, expectedValue, actualValue string) {
+		result.Mismatches = append(result.Mismatches, MerchantMismatch{Field: field, Expected: expectedValue, Actual: actualValue})
+		result.RiskScore = min(100, result.RiskScore+merchantFieldRisk[field])
+	}
+
+	// The QR must carry an account of the expected scheme
+	schemeFound := slices.ContainsFunc(sortedAccountTags(e), func(tag string) bool {
+		return e.MerchantAccountInfo[tag].PaymentScheme == expected.Scheme
+	})
+	if !schemeFound {
+		mismatch(MerchantFieldScheme, string(expected.Scheme), string(e.QRInfo().PaymentScheme))
+	}
+
+	// Every account must pay the merchant: an account added next to the genuine one
+	// diverts payments from apps that prefer it
+	for _, account := range e.Accounts() {
+		merchantID := account.MerchantID
+		if account.CardNetwork == "" {
+			merchantID = merchantAccountID(account.Tag, e.MerchantAccountInfo[account.Tag])
+		}
+		expectedID, registered := expected.accountID(account)
+		if !registered {
+			mismatch(MerchantFieldAccount, "registered account", merchantID)
+		} else if merchantID != expectedID {
+			mismatch(MerchantFieldMerchantID, expectedID, merchantID)
+		}
+	}
+
+	if expected.Name != "" && !sameMerchantText(expected.Name, e.MerchantName) {
+		mismatch(MerchantFieldName, expected.Name, e.MerchantName)
+	}
+	if expected.City != "" && !sameMerchantText(expected.City, e.MerchantCity) {
+		mismatch(MerchantFieldCity, expected.City, e.MerchantCity)
+	}
+	if expected.MCC != "" && expected.MCC != e.MerchantCategoryCode {
+		mismatch(MerchantFieldMCC, expected.MCC, e.MerchantCategoryCode)
+	}
+	return result
+}
+
+// accountID returns the merchant ID registered for an account, looked up by tag first,
+// then by the expected scheme and finally by the account's own scheme.
+func (m *ExpectedMerchant) accountID(account QRAccount) (string, bool) {
+	if id, exists := m.AccountIDs[account.Tag]; exists {
+		return id, true
+	}
+	if account.CardNetwork != "" {
+		return "", false
+	}
+	if account.PaymentScheme == m.Scheme {
+		return m.MerchantID, true
+	}
+	id, exists := m.AccountIDs[string(account.PaymentScheme)]
+	return id, exists
+}
+
+// merchantAccountID returns the identifier of a merchant account used for registry lookups.
+// PromptPay credit transfers (tag 29) are identified by their proxy, whichever sub-tag holds it.
+func merchantAccountID(tag string, account *MerchantAccount) string {
+	if tag == "29" && account.PaymentScheme == QRSchemePromptPay {
+		for _, proxy := range []string{account.MerchantID, account.Reference1, account.Reference2, account.Reference3} {
+			if proxy != "" {
+				return proxy
+			}
+		}
+	}
+	return account.MerchantID
+}
+
+// sortedAccountTags returns the tags of the non-nil merchant accounts in ascending order.
+func sortedAccountTags(e *EMVData) []string {
+	tags := make([]string, 0, len(e.MerchantAccountInfo))
+	for tag, account := range e.MerchantAccountInfo {
+		if account != nil {
+			tags = append(tags, tag)
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// sameMerchantText compares names or cities ignoring case and whitespace differences.
+func sameMerchantText(a, b string) bool {
+	return strings.EqualFold(RemoveDuplicateSpaces(a), RemoveDuplicateSpaces(b))
+}
+
+// merchantRegistryKey builds the in-memory registry key.
+func merchantRegistryKey(scheme QRPaymentScheme, merchantID string) string {
+	return string(scheme) + "|" + merchantID
+}
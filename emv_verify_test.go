@@ -0,0 +1,221 @@
+package xstr
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	verifyShopQR    = "00020101021129370016A000000677010111011300668123456785204581253037645802TH5913Som Tam  Shop6007BANGKOK63040897"
+	verifySwappedQR = "00020101021129370016A000000677010111011300668999999995204581253037645802TH5912Som Tam Shop6007BANGKOK6304A2F3"
+	verifyAddedQR   = "00020101021129370016A0000006770101110113006681234567831370016A000000677010111011300669999999995204581253037645802TH5912Som Tam Shop6007BANGKOK63047341"
+)
+
+var verifyShop = ExpectedMerchant{
+	Scheme:     QRSchemePromptPay,
+	MerchantID: "0066812345678",
+	Name:       "som tam shop",
+	City:       "Bangkok",
+	MCC:        "5812",
+}
+
+func TestMerchantVerifier_Verify(t *testing.T) {
+	verifier := NewMerchantVerifier(NewInMemoryMerchantRegistry(verifyShop))
+
+	tests := []struct {
+		name       string
+		qrString   string
+		registered bool
+		mismatches []MerchantMismatch
+		riskScore  int
+	}{
+		{
+			name:       "registered merchant with spacing differences",
+			qrString:   verifyShopQR,
+			registered: true,
+		},
+		{
+			name:     "swapped sticker",
+			qrString: verifySwappedQR,
+			mismatches: []MerchantMismatch{
+				{Field: MerchantFieldRegistration, Expected: "registered merchant"},
+			},
+			riskScore: 100,
+		},
+		{
+			name:       "fraudulent account added next to the genuine one",
+			qrString:   verifyAddedQR,
+			registered: true,
+			mismatches: []MerchantMismatch{
+				{Field: MerchantFieldMerchantID, Expected: "0066812345678", Actual: "0066999999999"},
+			},
+			riskScore: 100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			emvData, err := DecodeEMVQR(tt.qrString)
+			require.NoError(t, err)
+
+			result, err := verifier.Verify(context.Background(), emvData)
+			require.NoError(t, err)
+			assert.Equal(t, tt.registered, result.Registered)
+			assert.Equal(t, tt.mismatches, result.Mismatches)
+			assert.Equal(t, tt.riskScore, result.RiskScore)
+			assert.Equal(t, tt.registered && tt.mismatches == nil, result.OK())
+		})
+	}
+}
+
+func TestVerifyMerchant(t *testing.T) {
+	tests := []struct {
+		name       string
+		qrString   string
+		expected   ExpectedMerchant
+		mismatches []MerchantMismatch
+		riskScore  int
+	}{
+		{
+			name:     "matches",
+			qrString: verifyShopQR,
+			expected: verifyShop,
+		},
+		{
+			name:     "merchant ID replaced",
+			qrString: verifySwappedQR,
+			expected: verifyShop,
+			mismatches: []MerchantMismatch{
+				{Field: MerchantFieldMerchantID, Expected: "0066812345678", Actual: "0066899999999"},
+			},
+			riskScore: 100,
+		},
+		{
+			name:     "additional account of another merchant",
+			qrString: verifyAddedQR,
+			expected: verifyShop,
+			mismatches: []MerchantMismatch{
+				{Field: MerchantFieldMerchantID, Expected: "0066812345678", Actual: "0066999999999"},
+			},
+			riskScore: 100,
+		},
+		{
+			name:     "registered card network accounts",
+			qrString: cardNetworkQR,
+			expected: ExpectedMerchant{
+				Scheme:     QRSchemePromptPay,
+				MerchantID: "0066812345678",
+				AccountIDs: map[string]string{"02": "4111111111111111", "04": "5105105105105100", "15": "6220000012345678"},
+			},
+		},
+		{
+			name:     "card network account of another merchant",
+			qrString: cardNetworkQR,
+			expected: ExpectedMerchant{
+				Scheme:     QRSchemePromptPay,
+				MerchantID: "0066812345678",
+				AccountIDs: map[string]string{"02": "4000000000000002", "04": "5105105105105100", "15": "6220000012345678"},
+			},
+			mismatches: []MerchantMismatch{
+				{Field: MerchantFieldMerchantID, Expected: "4000000000000002", Actual: "4111111111111111"},
+			},
+			riskScore: 100,
+		},
+		{
+			name:     "unregistered card network account",
+			qrString: cardNetworkQR,
+			expected: ExpectedMerchant{
+				Scheme:     QRSchemePromptPay,
+				MerchantID: "0066812345678",
+				AccountIDs: map[string]string{"02": "4111111111111111", "04": "5105105105105100"},
+			},
+			mismatches: []MerchantMismatch{
+				{Field: MerchantFieldAccount, Expected: "registered account", Actual: "6220000012345678"},
+			},
+			riskScore: 100,
+		},
+		{
+			name:     "other scheme registered by scheme",
+			qrString: verifyAddedQR,
+			expected: ExpectedMerchant{
+				Scheme:     QRSchemePromptPay,
+				MerchantID: "0066812345678",
+				AccountIDs: map[string]string{"31": "0066999999999"},
+			},
+		},
+		{
+			name:     "name, city and MCC differ",
+			qrString: verifyShopQR,
+			expected: ExpectedMerchant{
+				Scheme:     QRSchemePromptPay,
+				MerchantID: "0066812345678",
+				Name:       "Noodle Bar",
+				City:       "Chiang Mai",
+				MCC:        "5814",
+			},
+			mismatches: []MerchantMismatch{
+				{Field: MerchantFieldName, Expected: "Noodle Bar", Actual: "Som Tam  Shop"},
+				{Field: MerchantFieldCity, Expected: "Chiang Mai", Actual: "BANGKOK"},
+				{Field: MerchantFieldMCC, Expected: "5814", Actual: "5812"},
+			},
+			riskScore: 80,
+		},
+		{
+			name:     "unchecked empty fields",
+			qrString: verifyShopQR,
+			expected: ExpectedMerchant{Scheme: QRSchemePromptPay, MerchantID: "0066812345678"},
+		},
+		{
+			name:     "different scheme",
+			qrString: verifyShopQR,
+			expected: ExpectedMerchant{Scheme: QRSchemeQRIS, MerchantID: "0066812345678"},
+			mismatches: []MerchantMismatch{
+				{Field: MerchantFieldScheme, Expected: "QRIS", Actual: "PromptPay"},
+				{Field: MerchantFieldAccount, Expected: "registered account", Actual: "0066812345678"},
+			},
+			riskScore: 100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			emvData, err := DecodeEMVQR(tt.qrString)
+			require.NoError(t, err)
+
+			result := VerifyMerchant(emvData, &tt.expected)
+			assert.True(t, result.Registered)
+			assert.Equal(t, tt.mismatches, result.Mismatches)
+			assert.Equal(t, tt.riskScore, result.RiskScore)
+		})
+	}
+}
+
+type failingMerchantRegistry struct{}
+
+func (failingMerchantRegistry) LookupMerchant(context.Context, QRPaymentScheme, string) (*ExpectedMerchant, error) {
+	return nil, errors.New("registry unavailable")
+}
+
+func TestMerchantVerifier_RegistryError(t *testing.T) {
+	emvData, err := DecodeEMVQR(verifyShopQR)
+	require.NoError(t, err)
+
+	_, err = NewMerchantVerifier(failingMerchantRegistry{}).Verify(context.Background(), emvData)
+	assert.EqualError(t, err, "registry unavailable")
+}
+
+func TestInMemoryMerchantRegistry(t *testing.T) {
+	registry := NewInMemoryMerchantRegistry()
+
+	_, err := registry.LookupMerchant(context.Background(), QRSchemePromptPay, "0066812345678")
+	assert.ErrorIs(t, err, ErrMerchantNotFound)
+
+	registry.Add(verifyShop)
+	merchant, err := registry.LookupMerchant(context.Background(), QRSchemePromptPay, "0066812345678")
+	require.NoError(t, err)
+	assert.Equal(t, verifyShop, *merchant)
+}
@@ -49,11 +49,15 @@ func MaskEmail(email string) string {
 
 // MaskPhone masks phone numbers for secure logging.
 // Shows country code and last 4 digits for international numbers.
+// Numbers too short to mask partially are fully masked.
 func MaskPhone(phone string) string {
 	if len(phone) == 0 {
 		return ""
 	}
 	if phone[0] == '+' {
+		if len(phone) <= 3 {
+			return "****"
+		}
 		if len(phone) <= 7 {
 			return phone[:3] + "****"
 		}
@@ -159,6 +159,16 @@ func TestMaskPhone(t *testing.T) {
 			input:    "+66123",
 			expected: "+66****",
 		},
+		{
+			name:     "plus sign only",
+			input:    "+",
+			expected: "****",
+		},
+		{
+			name:     "country code only",
+			input:    "+66",
+			expected: "****",
+		},
 		{
 			name:     "local number long",
 			input:    "0812345678",
@@ -399,3 +409,16 @@ func TestMaskPhone_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func FuzzMask(f *testing.F) {
+	seeds := []string{"", "1", "+", "+6", "+66", "+661234", "1234567890123456", "john@example.com", "@", "a@", "ก@ไทย.com", "+66****5678"}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, data string) {
+		_ = MaskSensitive(data)
+		_ = MaskEmail(data)
+		_ = MaskPhone(data)
+	})
+}
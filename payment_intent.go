@@ -0,0 +1,274 @@
+package xstr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// PayeeType represents the kind of identifier a payment is sent to.
+type PayeeType string
+
+// Payee type constants shared by all QR formats.
+const (
+	PayeeTypeMobile      PayeeType = "mobile"       // Mobile number proxy, in E.164 form
+	PayeeTypeNationalID  PayeeType = "national_id"  // National ID or tax ID proxy
+	PayeeTypeEWallet     PayeeType = "ewallet"      // E-wallet ID proxy
+	PayeeTypeBankAccount PayeeType = "bank_account" // Bank account proxy
+	PayeeTypeBiller      PayeeType = "biller"       // Biller ID of a bill payment
+	PayeeTypeMerchant    PayeeType = "merchant"     // Scheme-specific merchant ID
+	PayeeTypeMerchantPAN PayeeType = "merchant_pan" // Card network merchant PAN (tags 02-25)
+	PayeeTypeVPA         PayeeType = "vpa"          // UPI virtual payment address
+	PayeeTypeUEN         PayeeType = "uen"          // Singapore Unique Entity Number (PayNow)
+	PayeeTypeUnknown     PayeeType = "unknown"
+)
+
+// PaymentIntent field names reported by Missing.
+const (
+	PaymentIntentFieldPayeeID   = "payee_id"
+	PaymentIntentFieldPayeeType = "payee_type"
+	PaymentIntentFieldAmount    = "amount"
+	PaymentIntentFieldCurrency  = "currency"
+)
+
+// ErrPaymentIntentIncomplete is returned by Validate when a payment cannot be executed yet.
+var ErrPaymentIntentIncomplete = errors.New("payment intent is not executable")
+
+// PaymentIntent is a scheme-agnostic view of what a QR asks the payer to do,
+// suitable for payment orchestration regardless of the input format.
+type PaymentIntent struct {
+	Scheme        QRPaymentScheme `json:"scheme"`
+	PayeeID       string          `json:"payee_id"`
+	PayeeType     PayeeType       `json:"payee_type"`
+	PayeeName     string          `json:"payee_name,omitempty"`
+	AmountMinor   int64           `json:"amount_minor,omitempty"` // Amount in minor units, 0 when the payer enters it
+	Currency      string          `json:"currency,omitempty"`     // ISO 4217 alphabetic code, e.g. "THB"
+	CountryCode   string          `json:"country_code,omitempty"`
+	Reference1    string          `json:"reference_1,omitempty"`
+	Reference2    string          `json:"reference_2,omitempty"`
+	Reference3    string          `json:"reference_3,omitempty"`
+	ExpiresAt     *time.Time      `json:"expires_at,omitempty"` // Nil when the QR does not expire
+	POIMethodType POIMethodType   `json:"poi_method_type"`
+}
+
+// PaymentIntentSource is implemented by parsed QR formats that convert to a PaymentIntent,
+// so orchestration code can accept any of them.
+type PaymentIntentSource interface {
+	PaymentIntent() (*PaymentIntent, error)
+}
+
+// Missing lists the fields that must be filled in before the payment can be executed,
+// e.g. "amount" for a static QR where the payer enters the amount.
+func (p *PaymentIntent) Missing() []string {
+	var missing []string
+	if p.PayeeID == "" {
+		missing = append(missing, PaymentIntentFieldPayeeID)
+	}
+	if p.PayeeType == "" || p.PayeeType == PayeeTypeUnknown {
+		missing = append(missing, PaymentIntentFieldPayeeType)
+	}
+	if p.AmountMinor <= 0 {
+		missing = append(missing, PaymentIntentFieldAmount)
+	}
+	if p.Currency == "" {
+		missing = append(missing, PaymentIntentFieldCurrency)
+	}
+	return missing
+}
+
+// Validate reports whether the payment can be executed as is. The error wraps
+// ErrPaymentIntentIncomplete and lists the missing fields.
+func (p *PaymentIntent) Validate() error {
+	if missing := p.Missing(); len(missing) > 0 {
+		return fmt.Errorf("%w: missing %s", ErrPaymentIntentIncomplete, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// SetAmount sets the amount entered by the payer, in the intent's currency.
+func (p *PaymentIntent) SetAmount(amount string) error {
+	currency, ok := lookupCurrencyAlpha(p.Currency)
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrEMVUnknownCurrency, p.Currency)
+	}
+	minor, err := parseEMVAmountMinorUnits(amount, currency.MinorUnits)
+	if err != nil {
+		return err
+	}
+	p.AmountMinor = minor
+	return nil
+}
+
+// PaymentIntent converts the decoded QR to a PaymentIntent using its primary account:
+// the lowest GUI-based account (tags 26-51), or the lowest card network account when the
+// QR has none. PromptPay proxies are classified and mobile numbers converted to E.164,
+// and ExpiresAt is read with DefaultExpiryParsers; see PaymentIntentWithExpiry.
+//
+// Examples:
+//   - PromptPay mobile QR for 10.00 THB -> {Scheme: "PromptPay", PayeeID: "+66812345678", PayeeType: "mobile", AmountMinor: 1000, Currency: "THB"}
+func (e *EMVData) PaymentIntent() (*PaymentIntent, error) {
+	return e.PaymentIntentWithExpiry()
+}
+
+// PaymentIntentWithExpiry converts the decoded QR like PaymentIntent, reading ExpiresAt
+// with the given expiry parsers, e.g. a ThaiBankExpiryParser for the bank's layout.
+func (e *EMVData) PaymentIntentWithExpiry(parsers ...ExpiryParser) (*PaymentIntent, error) {
+	intent := &PaymentIntent{
+		Scheme:        QRSchemeUnknown,
+		PayeeType:     PayeeTypeUnknown,
+		PayeeName:     e.MerchantName,
+		CountryCode:   e.CountryCode,
+		POIMethodType: e.POIMethodType,
+	}
+	if err := setIntentAmount(intent, e.TransactionAmount, e.TransactionCurrency); err != nil {
+		return nil, err
+	}
+	validity, err := e.Validity(parsers...)
+	if err != nil {
+		return nil, err
+	}
+	if validity != nil {
+		intent.ExpiresAt = validity.ExpiresAt
+	}
+
+	accounts := e.Accounts()
+	for _, account := range accounts {
+		if account.CardNetwork == "" {
+			setIntentPayee(intent, e, account)
+			return intent, nil
+		}
+	}
+	if len(accounts) > 0 {
+		intent.PayeeID = accounts[0].MerchantID
+		intent.PayeeType = PayeeTypeMerchantPAN
+	}
+	return intent, nil
+}
+
+// PaymentIntent converts the PromptPay view to a PaymentIntent. Tag 30 QRs are paid to the
+// biller ID; tag 29 proxies are validated and classified by their ProxyType.
+func (i *EMVCoQRInfo) PaymentIntent() (*PaymentIntent, error) {
+	intent := &PaymentIntent{
+		Scheme:        QRSchemePromptPay,
+		PayeeType:     PayeeTypeUnknown,
+		CountryCode:   i.CountryCode,
+		Reference3:    i.Ref3,
+		POIMethodType: mapPOIMethodType(i.Format),
+	}
+	if err := setIntentAmount(intent, i.Amount, i.CurrencyISO4217); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case i.BillerID != "":
+		intent.PayeeID = i.BillerID
+		intent.PayeeType = PayeeTypeBiller
+		intent.Reference1 = i.Ref1
+		intent.Reference2 = i.Ref2
+	case i.PhoneNumber != "":
+		intent.PayeeID = i.PhoneNumber
+		proxyValue := i.PhoneNumber
+		if i.ProxyType == PromptPayProxyMobile {
+			proxyValue = "00" + proxyValue
+		}
+		if proxy, err := ParsePromptPayProxy(promptPayProxySubTag(i.ProxyType), proxyValue); err == nil {
+			setIntentProxy(intent, proxy)
+		}
+	}
+	return intent, nil
+}
+
+// setIntentPayee fills the payee and references from a GUI-based account.
+func setIntentPayee(intent *PaymentIntent, e *EMVData, account QRAccount) {
+	intent.Scheme = account.PaymentScheme
+	intent.PayeeID = account.MerchantID
+	intent.PayeeType = PayeeTypeMerchant
+	intent.Reference1 = account.Reference1
+	intent.Reference2 = account.Reference2
+
+	if account.PaymentScheme == QRSchemePayNow {
+		setIntentPayNowPayee(intent, account)
+		return
+	}
+	if account.PaymentScheme != QRSchemePromptPay {
+		return
+	}
+
+	// Thai bill payment convention: Ref3 in the terminal label, falling back to the reference label
+	intent.Reference3 = e.AdditionalData["07"]
+	if intent.Reference3 == "" {
+		intent.Reference3 = e.AdditionalData["05"]
+	}
+	switch account.Tag {
+	case "29":
+		// Proxies occupy the reference sub-tags, so they are not references
+		intent.Reference1, intent.Reference2 = "", ""
+		intent.PayeeType = PayeeTypeUnknown
+		if proxy, err := e.PromptPayProxy(); err == nil {
+			setIntentProxy(intent, proxy)
+		} else {
+			intent.PayeeID = merchantAccountID(account.Tag, e.MerchantAccountInfo[account.Tag])
+		}
+	case "30":
+		intent.PayeeType = PayeeTypeBiller
+	}
+}
+
+// setIntentPayNowPayee sets the payee from a PayNow account: sub-field 01 holds the proxy
+// type ("0" mobile, "2" UEN) and 02 the proxy value; 03 and 04 are the editable flag and
+// expiry date, not references.
+func setIntentPayNowPayee(intent *PaymentIntent, account QRAccount) {
+	intent.PayeeID = account.Reference1
+	intent.Reference1, intent.Reference2 = "", ""
+	switch account.MerchantID {
+	case "0":
+		intent.PayeeType = PayeeTypeMobile
+	case "2":
+		intent.PayeeType = PayeeTypeUEN
+	default:
+		intent.PayeeType = PayeeTypeUnknown
+	}
+}
+
+// setIntentProxy sets the payee from a classified PromptPay proxy.
+func setIntentProxy(intent *PaymentIntent, proxy *PromptPayProxy) {
+	intent.PayeeID = proxy.Value
+	switch proxy.Type {
+	case PromptPayProxyMobile:
+		intent.PayeeID = proxy.E164
+		intent.PayeeType = PayeeTypeMobile
+	case PromptPayProxyNationalID:
+		intent.PayeeType = PayeeTypeNationalID
+	case PromptPayProxyEWallet:
+		intent.PayeeType = PayeeTypeEWallet
+	case PromptPayProxyBankAccount:
+		intent.PayeeType = PayeeTypeBankAccount
+	}
+}
+
+// setIntentAmount converts an EMV amount and numeric currency to minor units and alpha code.
+func setIntentAmount(intent *PaymentIntent, amount, numericCurrency string) error {
+	if numericCurrency == "" {
+		if amount != "" {
+			return fmt.Errorf("%w: amount %q without currency", ErrEMVUnknownCurrency, amount)
+		}
+		return nil
+	}
+
+	currency, ok := lookupCurrency(numericCurrency)
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrEMVUnknownCurrency, numericCurrency)
+	}
+	intent.Currency = currency.Alpha
+	if amount == "" {
+		return nil
+	}
+
+	minor, err := parseEMVAmountMinorUnits(amount, currency.MinorUnits)
+	if err != nil {
+		return err
+	}
+	intent.AmountMinor = minor
+	return nil
+}
@@ -0,0 +1,237 @@
+package xstr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	intentMobileQR     = "00020101021229370016A000000677010111011300668123456785303764540510.005802TH5907SOM TAM62100506INV0016304C418"
+	intentNationalIDQR = "00020101021129370016A0000006770101110213110170020345053037645802TH630409B9"
+)
+
+func TestEMVData_PaymentIntent(t *testing.T) {
+	tests := []struct {
+		name     string
+		qrString string
+		expected *PaymentIntent
+		missing  []string
+	}{
+		{
+			name:     "PromptPay mobile with amount",
+			qrString: intentMobileQR,
+			expected: &PaymentIntent{
+				Scheme:        QRSchemePromptPay,
+				PayeeID:       "+66812345678",
+				PayeeType:     PayeeTypeMobile,
+				PayeeName:     "SOM TAM",
+				AmountMinor:   1000,
+				Currency:      "THB",
+				CountryCode:   "TH",
+				Reference3:    "INV001",
+				POIMethodType: POITypeDynamic,
+			},
+		},
+		{
+			name:     "PromptPay national ID without amount",
+			qrString: intentNationalIDQR,
+			expected: &PaymentIntent{
+				Scheme:        QRSchemePromptPay,
+				PayeeID:       "1101700203450",
+				PayeeType:     PayeeTypeNationalID,
+				Currency:      "THB",
+				CountryCode:   "TH",
+				POIMethodType: POITypeStatic,
+			},
+			missing: []string{PaymentIntentFieldAmount},
+		},
+		{
+			name:     "PromptPay bill payment",
+			qrString: billDynamicQR,
+			expected: &PaymentIntent{
+				Scheme:        QRSchemePromptPay,
+				PayeeID:       "099400016550100",
+				PayeeType:     PayeeTypeBiller,
+				AmountMinor:   12550,
+				Currency:      "THB",
+				CountryCode:   "TH",
+				Reference1:    "INV001",
+				Reference2:    "CUST42",
+				POIMethodType: POITypeDynamic,
+			},
+		},
+		{
+			name:     "multi-account QRIS uses the lowest GUI account",
+			qrString: multiAccountQR,
+			expected: &PaymentIntent{
+				Scheme:        QRSchemeQRIS,
+				PayeeID:       "936000140000001",
+				PayeeType:     PayeeTypeMerchant,
+				Currency:      "IDR",
+				CountryCode:   "ID",
+				Reference1:    "ID1020000001",
+				POIMethodType: POITypeStatic,
+			},
+			missing: []string{PaymentIntentFieldAmount},
+		},
+		{
+			name:     "QRIS ignores the Thai Ref3 convention",
+			qrString: "00020101021126320014ID.CO.QRIS.WWW0110ID1020000153033605802ID5904SHOP62100506INV0016304D5D1",
+			expected: &PaymentIntent{
+				Scheme:        QRSchemeQRIS,
+				PayeeID:       "ID10200001",
+				PayeeType:     PayeeTypeMerchant,
+				PayeeName:     "SHOP",
+				Currency:      "IDR",
+				CountryCode:   "ID",
+				POIMethodType: POITypeStatic,
+			},
+			missing: []string{PaymentIntentFieldAmount},
+		},
+		{
+			name:     "card network account only",
+			qrString: "0002010102110216411111111111111253037645802TH630430C5",
+			expected: &PaymentIntent{
+				Scheme:        QRSchemeUnknown,
+				PayeeID:       "4111111111111112",
+				PayeeType:     PayeeTypeMerchantPAN,
+				Currency:      "THB",
+				CountryCode:   "TH",
+				POIMethodType: POITypeStatic,
+			},
+			missing: []string{PaymentIntentFieldAmount},
+		},
+		{
+			name:     "no account",
+			qrString: interopUSQR,
+			expected: &PaymentIntent{
+				Scheme:        QRSchemeUnknown,
+				PayeeType:     PayeeTypeUnknown,
+				Currency:      "USD",
+				CountryCode:   "US",
+				POIMethodType: POITypeStatic,
+			},
+			missing: []string{PaymentIntentFieldPayeeID, PaymentIntentFieldPayeeType, PaymentIntentFieldAmount},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			emvData, err := DecodeEMVQR(tt.qrString)
+			require.NoError(t, err)
+
+			intent, err := emvData.PaymentIntent()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, intent)
+			assert.Equal(t, tt.missing, intent.Missing())
+			if tt.missing == nil {
+				assert.NoError(t, intent.Validate())
+			} else {
+				assert.ErrorIs(t, intent.Validate(), ErrPaymentIntentIncomplete)
+			}
+		})
+	}
+}
+
+func TestEMVData_PaymentIntent_InvalidAmount(t *testing.T) {
+	_, err := (&EMVData{TransactionAmount: "10.00", TransactionCurrency: "999"}).PaymentIntent()
+	assert.ErrorIs(t, err, ErrEMVUnknownCurrency)
+
+	_, err = (&EMVData{TransactionAmount: "10.001", TransactionCurrency: "764"}).PaymentIntent()
+	assert.ErrorIs(t, err, ErrEMVInvalidAmount)
+}
+
+func TestEMVData_PaymentIntent_OtherCurrencies(t *testing.T) {
+	tests := []struct {
+		name          string
+		currency      string
+		amount        string
+		country       string
+		expectedAlpha string
+		expectedMinor int64
+	}{
+		{name: "Bangladeshi taka", currency: "050", amount: "250.50", country: "BD", expectedAlpha: "BDT", expectedMinor: 25050},
+		{name: "Kuwaiti dinar", currency: "414", amount: "1.250", country: "KW", expectedAlpha: "KWD", expectedMinor: 1250},
+		{name: "West African CFA franc", currency: "952", amount: "500", country: "SN", expectedAlpha: "XOF", expectedMinor: 500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			staticQR := AppendEMVCRC("000201010211" + "02164111111111111111" + "5303" + tt.currency + "5802" + tt.country)
+			dynamicQR, err := ToDynamicEMVQR(staticQR, tt.amount)
+			require.NoError(t, err)
+
+			emvData, err := DecodeEMVQR(dynamicQR)
+			require.NoError(t, err)
+			minor, err := emvData.AmountMinorUnits()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedMinor, minor)
+
+			intent, err := emvData.PaymentIntent()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedAlpha, intent.Currency)
+			assert.Equal(t, tt.expectedMinor, intent.AmountMinor)
+		})
+	}
+}
+
+func TestEMVCoQRInfo_PaymentIntent(t *testing.T) {
+	tests := []struct {
+		name      string
+		qrString  string
+		payeeID   string
+		payeeType PayeeType
+		amount    int64
+	}{
+		{name: "mobile", qrString: intentMobileQR, payeeID: "+66812345678", payeeType: PayeeTypeMobile, amount: 1000},
+		{name: "national ID", qrString: intentNationalIDQR, payeeID: "1101700203450", payeeType: PayeeTypeNationalID},
+		{name: "bill payment", qrString: billDynamicQR, payeeID: "099400016550100", payeeType: PayeeTypeBiller, amount: 12550},
+		{name: "bank account", qrString: "00020101021129340016A000000677010111041012345678905802TH530376463041325", payeeID: "1234567890", payeeType: PayeeTypeBankAccount},
+		// Also a well-formed mobile number, classified by its sub-tag
+		{name: "national ID in mobile format", qrString: "00020101021129370016A0000006770101110213006681234567953037645802TH6304F44A", payeeID: "0066812345679", payeeType: PayeeTypeNationalID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := ParseEMVCoQRString(tt.qrString)
+			require.NoError(t, err)
+
+			intent, err := info.PaymentIntent()
+			require.NoError(t, err)
+			assert.Equal(t, QRSchemePromptPay, intent.Scheme)
+			assert.Equal(t, tt.payeeID, intent.PayeeID)
+			assert.Equal(t, tt.payeeType, intent.PayeeType)
+			assert.Equal(t, tt.amount, intent.AmountMinor)
+			assert.Equal(t, "THB", intent.Currency)
+		})
+	}
+}
+
+func TestPaymentIntent_SetAmount(t *testing.T) {
+	intent := &PaymentIntent{PayeeID: "1101700203450", PayeeType: PayeeTypeNationalID, Currency: "THB"}
+	assert.ErrorIs(t, intent.Validate(), ErrPaymentIntentIncomplete)
+	assert.EqualError(t, intent.Validate(), "payment intent is not executable: missing amount")
+
+	require.NoError(t, intent.SetAmount("99.5"))
+	assert.Equal(t, int64(9950), intent.AmountMinor)
+	assert.NoError(t, intent.Validate())
+
+	assert.ErrorIs(t, intent.SetAmount("1.234"), ErrEMVInvalidAmount)
+	assert.ErrorIs(t, (&PaymentIntent{Currency: "XXX"}).SetAmount("1"), ErrEMVUnknownCurrency)
+}
+
+func TestPaymentIntentSource(t *testing.T) {
+	emvData, err := DecodeEMVQR(intentMobileQR)
+	require.NoError(t, err)
+	info := emvData.EMVCoQRInfo()
+	upi, err := ParseUPILink("upi://pay?pa=shop@okbank&am=10")
+	require.NoError(t, err)
+
+	for _, source := range []PaymentIntentSource{emvData, &info, upi} {
+		intent, err := source.PaymentIntent()
+		require.NoError(t, err)
+		assert.NoError(t, intent.Validate())
+	}
+}
@@ -186,7 +186,7 @@ func ValidatePhoneCurrency(phoneNumber, currencyCode string) error {
 //   - 0812345678 (Thai domestic)
 //   - 812345678 (9 digits, assumes Thai)
 //
-// Returns E.164 format (+66812345678) or error if invalid.
+// Returns E.164 format (+66812345678) or error if invalid. It never panics on malformed input.
 func NormalizePhoneToE164(phoneNumber string) (string, error) {
 	// Clean input
 	phone := cleanPhoneInput(phoneNumber)
@@ -632,3 +632,22 @@ func TestCurrencyToCountryMapping(t *testing.T) {
 		})
 	}
 }
+
+func FuzzNormalizePhoneToE164(f *testing.F) {
+	seeds := []string{"+66812345678", "66812345678", "0812345678", "812345678", "+1", "+", "", "(081)234-5678", "+66 81 234 5678", "abc"}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, phone string) {
+		e164, err := NormalizePhoneToE164(phone)
+		if err != nil {
+			return
+		}
+		// A normalized number must be stable under normalization
+		again, err := NormalizePhoneToE164(e164)
+		if err != nil || again != e164 {
+			t.Fatalf("normalizing %q twice gave %q, %v", e164, again, err)
+		}
+	})
+}
@@ -0,0 +1,161 @@
+package xstr
+
+import (
+	"errors"
+	"strings"
+)
+
+// PromptPayProxyType represents the kind of proxy identifier in a PromptPay tag 29.
+type PromptPayProxyType string
+
+// PromptPay proxy type constants mapped from tag 29 sub-tags
+const (
+	PromptPayProxyMobile      PromptPayProxyType = "mobile"       // Sub-tag 01: Mobile number
+	PromptPayProxyNationalID  PromptPayProxyType = "national_id"  // Sub-tag 02: National ID / Tax ID
+	PromptPayProxyEWallet     PromptPayProxyType = "ewallet"      // Sub-tag 03: E-wallet ID
+	PromptPayProxyBankAccount PromptPayProxyType = "bank_account" // Sub-tag 04: Bank account
+	PromptPayProxyUnknown     PromptPayProxyType = "unknown"
+)
+
+// Common PromptPay proxy errors.
+var (
+	ErrPromptPayProxyNotFound = errors.New("promptpay proxy not found")
+	ErrInvalidPromptPayProxy  = errors.New("invalid promptpay proxy")
+	ErrInvalidThaiNationalID  = errors.New("invalid thai national id")
+)
+
+// PromptPayProxy represents a classified and validated PromptPay proxy identifier.
+type PromptPayProxy struct {
+	Type   PromptPayProxyType `json:"type"`           // Classified proxy type
+	SubTag string             `json:"sub_tag"`        // Tag 29 sub-tag the proxy was read from
+	Value  string             `json:"value"`          // Raw proxy value as encoded in the QR
+	E164   string             `json:"e164,omitempty"` // E.164 phone number (mobile proxies only)
+}
+
+// PromptPayProxy extracts the typed proxy from the PromptPay credit transfer account (tag 29).
+// Returns ErrPromptPayProxyNotFound if the QR carries no PromptPay proxy.
+func (e *EMVData) PromptPayProxy() (*PromptPayProxy, error) {
+	account, exists := e.MerchantAccountInfo["29"]
+	if !exists || account.PaymentScheme != QRSchemePromptPay {
+		return nil, ErrPromptPayProxyNotFound
+	}
+
+	// Proxy sub-tags are mutually exclusive, the first present one wins
+	proxies := []struct {
+		subTag string
+		value  string
+	}{
+		{"01", account.MerchantID},
+		{"02", account.Reference1},
+		{"03", account.Reference2},
+		{"04", account.Reference3},
+	}
+	for _, proxy := range proxies {
+		if proxy.value != "" {
+			return ParsePromptPayProxy(proxy.subTag, proxy.value)
+		}
+	}
+
+	return nil, ErrPromptPayProxyNotFound
+}
+
+// ParsePromptPayProxy classifies and validates a proxy value by its tag 29 sub-tag.
+// Mobile proxies are converted to E.164 and national IDs are checked against the Thai checksum.
+//
+// Examples:
+//   - ParsePromptPayProxy("01", "0066812345678") -> mobile, E164 "+66812345678"
+//   - ParsePromptPayProxy("02", "1101700203450") -> national_id
+//   - ParsePromptPayProxy("02", "1101700203451") -> ErrInvalidThaiNationalID
+func ParsePromptPayProxy(subTag, value string) (*PromptPayProxy, error) {
+	proxy := &PromptPayProxy{
+		Type:   mapPromptPayProxyType(subTag),
+		SubTag: subTag,
+		Value:  value,
+	}
+
+	switch proxy.Type {
+	case PromptPayProxyMobile:
+		// Mobile proxies are encoded as 00 + country code + national number
+		if len(value) != 13 || !isDigits(value) || !strings.HasPrefix(value, "00") {
+			return nil, ErrInvalidPhoneFormat
+		}
+		e164, err := NormalizePhoneToE164("+" + value[2:])
+		if err != nil {
+			return nil, err
+		}
+		proxy.E164 = e164
+	case PromptPayProxyNationalID:
+		if !IsValidThaiNationalID(value) {
+			return nil, ErrInvalidThaiNationalID
+		}
+	case PromptPayProxyEWallet:
+		if len(value) != 15 || !isDigits(value) {
+			return nil, ErrInvalidPromptPayProxy
+		}
+	case PromptPayProxyBankAccount:
+		if value == "" || !isDigits(value) {
+			return nil, ErrInvalidPromptPayProxy
+		}
+	default:
+		return nil, ErrInvalidPromptPayProxy
+	}
+
+	return proxy, nil
+}
+
+// Masked returns the proxy value masked for display and logging.
+// Mobile proxies use MaskPhone on the E.164 form, other proxies use MaskSensitive.
+func (p *PromptPayProxy) Masked() string {
+	if p.Type == PromptPayProxyMobile && p.E164 != "" {
+		return MaskPhone(p.E164)
+	}
+	return MaskSensitive(p.Value)
+}
+
+// IsValidThaiNationalID validates a 13-digit Thai national ID or tax ID checksum.
+// The check digit is (11 - sum(d[i] * (13 - i)) mod 11) mod 10 over the first 12 digits.
+func IsValidThaiNationalID(id string) bool {
+	if len(id) != 13 || !isDigits(id) {
+		return false
+	}
+
+	sum := 0
+	for i := 0; i < 12; i++ {
+		sum += int(id[i]-'0') * (13 - i)
+	}
+	checkDigit := (11 - sum%11) % 10
+
+	return checkDigit == int(id[12]-'0')
+}
+
+// promptPayProxySubTag returns the tag 29 sub-tag of a proxy type, or "" if it has none.
+func promptPayProxySubTag(proxyType PromptPayProxyType) string {
+	switch proxyType {
+	case PromptPayProxyMobile:
+		return "01"
+	case PromptPayProxyNationalID:
+		return "02"
+	case PromptPayProxyEWallet:
+		return "03"
+	case PromptPayProxyBankAccount:
+		return "04"
+	default:
+		return ""
+	}
+}
+
+// mapPromptPayProxyType converts tag 29 sub-tags to proxy types.
+func mapPromptPayProxyType(subTag string) PromptPayProxyType {
+	switch subTag {
+	case "01":
+		return PromptPayProxyMobile
+	case "02":
+		return PromptPayProxyNationalID
+	case "03":
+		return PromptPayProxyEWallet
+	case "04":
+		return PromptPayProxyBankAccount
+	default:
+		return PromptPayProxyUnknown
+	}
+}
@@ -0,0 +1,128 @@
+package xstr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEMVData_PromptPayProxy(t *testing.T) {
+	tests := []struct {
+		name       string
+		qrString   string
+		wantErr    error
+		wantType   PromptPayProxyType
+		wantValue  string
+		wantE164   string
+		wantMasked string
+	}{
+		{
+			name:       "mobile proxy",
+			qrString:   "00020101021129370016A000000677010111011300668123456785802TH5303764540510.0063044ABE",
+			wantType:   PromptPayProxyMobile,
+			wantValue:  "0066812345678",
+			wantE164:   "+66812345678",
+			wantMasked: "+66****5678",
+		},
+		{
+			name:       "national ID proxy",
+			qrString:   "00020101021129370016A000000677010111021311017002034505802TH53037646304D605",
+			wantType:   PromptPayProxyNationalID,
+			wantValue:  "1101700203450",
+			wantMasked: "1101****3450",
+		},
+		{
+			name:       "e-wallet proxy",
+			qrString:   "00020101021129390016A00000067701011103150040000012345675802TH53037646304DF15",
+			wantType:   PromptPayProxyEWallet,
+			wantValue:  "004000001234567",
+			wantMasked: "0040****4567",
+		},
+		{
+			name:     "national ID with bad checksum",
+			qrString: "00020101021129370016A000000677010111021312345678901235802TH53037646304EC40",
+			wantErr:  ErrInvalidThaiNationalID,
+		},
+		{
+			name:     "bill payment has no proxy",
+			qrString: "00020101021230590016A000000677010112011501075370008820502061234560306ABCDEF530376454031005802TH62210105INV010708TERM00016304C43B",
+			wantErr:  ErrPromptPayProxyNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			emvData, err := DecodeEMVQR(tt.qrString)
+			require.NoError(t, err)
+
+			proxy, err := emvData.PromptPayProxy()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, proxy)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantType, proxy.Type)
+			assert.Equal(t, tt.wantValue, proxy.Value)
+			assert.Equal(t, tt.wantE164, proxy.E164)
+			assert.Equal(t, tt.wantMasked, proxy.Masked())
+		})
+	}
+}
+
+func TestParsePromptPayProxy(t *testing.T) {
+	tests := []struct {
+		name     string
+		subTag   string
+		value    string
+		wantErr  error
+		wantType PromptPayProxyType
+	}{
+		{"mobile", "01", "0066812345678", nil, PromptPayProxyMobile},
+		{"mobile without 00 prefix", "01", "0812345678", ErrInvalidPhoneFormat, ""},
+		{"national ID", "02", "1101700203450", nil, PromptPayProxyNationalID},
+		{"national ID too short", "02", "110170020345", ErrInvalidThaiNationalID, ""},
+		{"e-wallet", "03", "004000001234567", nil, PromptPayProxyEWallet},
+		{"e-wallet wrong length", "03", "0040000012345", ErrInvalidPromptPayProxy, ""},
+		{"bank account", "04", "0041234567890", nil, PromptPayProxyBankAccount},
+		{"bank account non-numeric", "04", "004-123", ErrInvalidPromptPayProxy, ""},
+		{"unknown sub-tag", "05", "123", ErrInvalidPromptPayProxy, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proxy, err := ParsePromptPayProxy(tt.subTag, tt.value)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, proxy)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantType, proxy.Type)
+			assert.Equal(t, tt.subTag, proxy.SubTag)
+		})
+	}
+}
+
+func TestIsValidThaiNationalID(t *testing.T) {
+	tests := []struct {
+		id   string
+		want bool
+	}{
+		{"1101700203450", true},
+		{"3100600445597", true},
+		{"1101700203451", false},
+		{"110170020345", false},
+		{"110170020345A", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsValidThaiNationalID(tt.id))
+		})
+	}
+}
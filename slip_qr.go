@@ -0,0 +1,146 @@
+package xstr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Thai bank slip QR constants.
+const (
+	slipCRCTag      = "9104" // CRC tag and length header
+	slipCountryCode = "TH"   // Country code carried in tag 51
+)
+
+// ErrInvalidSlipQR is returned when a slip QR is missing or has malformed fields.
+var ErrInvalidSlipQR = errors.New("invalid slip QR")
+
+// SlipQR represents the mini-QR printed on Thai bank transfer slips.
+// Field names follow the inputs of bank slip verification APIs.
+type SlipQR struct {
+	APIID       string    `json:"api_id"`         // Tag 00-00: API ID, e.g. "000001"
+	SendingBank string    `json:"sending_bank"`   // Tag 00-01: Sending bank code, e.g. "004"
+	TransRef    string    `json:"trans_ref"`      // Tag 00-02: Transaction reference
+	CountryCode string    `json:"country_code"`   // Tag 51: Country code, "TH"
+	CRC         string    `json:"crc"`            // Tag 91: CRC-16
+	Bank        *ThaiBank `json:"bank,omitempty"` // Sending bank details, nil for unknown codes
+}
+
+// ThaiBank describes a Thai bank by its Bank of Thailand code.
+type ThaiBank struct {
+	Code         string `json:"code"`
+	Abbreviation string `json:"abbreviation"`
+	Name         string `json:"name"`
+}
+
+// thaiBanks maps Bank of Thailand bank codes to banks.
+var thaiBanks = map[string]ThaiBank{
+	"002": {Code: "002", Abbreviation: "BBL", Name: "Bangkok Bank"},
+	"004": {Code: "004", Abbreviation: "KBANK", Name: "Kasikornbank"},
+	"006": {Code: "006", Abbreviation: "KTB", Name: "Krungthai Bank"},
+	"011": {Code: "011", Abbreviation: "TTB", Name: "TMBThanachart Bank"},
+	"014": {Code: "014", Abbreviation: "SCB", Name: "Siam Commercial Bank"},
+	"017": {Code: "017", Abbreviation: "CITI", Name: "Citibank"},
+	"020": {Code: "020", Abbreviation: "SCBT", Name: "Standard Chartered Bank (Thai)"},
+	"022": {Code: "022", Abbreviation: "CIMBT", Name: "CIMB Thai Bank"},
+	"024": {Code: "024", Abbreviation: "UOBT", Name: "United Overseas Bank (Thai)"},
+	"025": {Code: "025", Abbreviation: "BAY", Name: "Bank of Ayudhya (Krungsri)"},
+	"030": {Code: "030", Abbreviation: "GSB", Name: "Government Savings Bank"},
+	"033": {Code: "033", Abbreviation: "GHB", Name: "Government Housing Bank"},
+	"034": {Code: "034", Abbreviation: "BAAC", Name: "Bank for Agriculture and Agricultural Cooperatives"},
+	"035": {Code: "035", Abbreviation: "EXIM", Name: "Export-Import Bank of Thailand"},
+	"052": {Code: "052", Abbreviation: "BOC", Name: "Bank of China (Thai)"},
+	"066": {Code: "066", Abbreviation: "ISBT", Name: "Islamic Bank of Thailand"},
+	"067": {Code: "067", Abbreviation: "TISCO", Name: "TISCO Bank"},
+	"069": {Code: "069", Abbreviation: "KKP", Name: "Kiatnakin Phatra Bank"},
+	"070": {Code: "070", Abbreviation: "ICBCT", Name: "ICBC (Thai)"},
+	"071": {Code: "071", Abbreviation: "TCD", Name: "Thai Credit Bank"},
+	"073": {Code: "073", Abbreviation: "LHFG", Name: "Land and Houses Bank"},
+	"098": {Code: "098", Abbreviation: "SME", Name: "SME Development Bank"},
+}
+
+// LookupThaiBank returns the bank for a 3-digit Bank of Thailand code.
+//
+// Examples:
+//   - LookupThaiBank("004") -> {Code: "004", Abbreviation: "KBANK", Name: "Kasikornbank"}, true
+func LookupThaiBank(code string) (ThaiBank, bool) {
+	bank, ok := thaiBanks[code]
+	return bank, ok
+}
+
+// IsSlipQR reports whether a QR string looks like a bank slip QR rather than an EMV
+// merchant QR: it starts with a tag 00 template and ends with a tag 91 CRC field.
+func IsSlipQR(qrString string) bool {
+	return len(qrString) >= 12 &&
+		strings.HasPrefix(qrString, "00") &&
+		qrString[len(qrString)-8:len(qrString)-4] == slipCRCTag
+}
+
+// DecodeSlipQR decodes and validates a Thai bank transfer slip mini-QR.
+// The CRC (tag 91) must be the last field and match; the API ID, sending bank code,
+// transaction reference and country code "TH" (tag 51) are required. Unknown bank
+// codes are accepted with a nil Bank so new banks do not break verification.
+func DecodeSlipQR(qrString string) (*SlipQR, error) {
+	qrString = strings.TrimSpace(qrString)
+	if len(qrString) < 12 {
+		return nil, ErrEMVTooShort
+	}
+	if len(qrString) > EMVMaxPayloadLength {
+		return nil, ErrEMVPayloadTooLong
+	}
+	if err := verifySlipCRC(qrString); err != nil {
+		return nil, err
+	}
+
+	slip := &SlipQR{}
+	for field, err := range EMVTLVSeq(qrString) {
+		if err != nil {
+			return nil, err
+		}
+		switch field.Tag {
+		case "00":
+			subFields, err := parseSubFields(field.Value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidSlipQR, err)
+			}
+			slip.APIID = subFields["00"]
+			slip.SendingBank = subFields["01"]
+			slip.TransRef = subFields["02"]
+		case "51":
+			slip.CountryCode = field.Value
+		case "91":
+			slip.CRC = field.Value
+		}
+	}
+
+	switch {
+	case slip.APIID == "":
+		return nil, fmt.Errorf("%w: missing API ID", ErrInvalidSlipQR)
+	case len(slip.SendingBank) != 3 || !isDigits(slip.SendingBank):
+		return nil, fmt.Errorf("%w: invalid sending bank code %q", ErrInvalidSlipQR, slip.SendingBank)
+	case slip.TransRef == "":
+		return nil, fmt.Errorf("%w: missing transaction reference", ErrInvalidSlipQR)
+	case slip.CountryCode != slipCountryCode:
+		return nil, fmt.Errorf("%w: country code %q must be %q", ErrInvalidSlipQR, slip.CountryCode, slipCountryCode)
+	}
+
+	if bank, ok := LookupThaiBank(slip.SendingBank); ok {
+		slip.Bank = &bank
+	}
+	return slip, nil
+}
+
+// verifySlipCRC verifies the terminal tag 91 CRC of a slip QR.
+func verifySlipCRC(qrString string) error {
+	if qrString[len(qrString)-8:len(qrString)-4] != slipCRCTag {
+		return fmt.Errorf("%w: tag 91", ErrEMVCRCNotFound)
+	}
+
+	payload := qrString[:len(qrString)-8]
+	actualCRC := qrString[len(qrString)-4:]
+	calculated := updateCRC16(updateCRC16(0xFFFF, payload), slipCRCTag)
+	if value, ok := parseCRCHex(actualCRC); ok && value == calculated {
+		return nil
+	}
+	return fmt.Errorf("%w: expected %04X, got %s", ErrEMVCRCMismatch, calculated, actualCRC)
+}
@@ -0,0 +1,105 @@
+package xstr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	slipKBankQR   = "00370006000001010300402162023051912345ABC5102TH910484C3"
+	slipSCBQR     = "0041000600000101030140220015166150934BPP045785102TH91040885"
+	slipUnknownQR = "0025000600000101039990204REF15102TH9104DFA7"
+)
+
+func TestDecodeSlipQR(t *testing.T) {
+	tests := []struct {
+		name     string
+		qrString string
+		expected *SlipQR
+	}{
+		{
+			name:     "KBank slip",
+			qrString: slipKBankQR,
+			expected: &SlipQR{
+				APIID:       "000001",
+				SendingBank: "004",
+				TransRef:    "2023051912345ABC",
+				CountryCode: "TH",
+				CRC:         "84C3",
+				Bank:        &ThaiBank{Code: "004", Abbreviation: "KBANK", Name: "Kasikornbank"},
+			},
+		},
+		{
+			name:     "SCB slip with surrounding whitespace",
+			qrString: " " + slipSCBQR + "\n",
+			expected: &SlipQR{
+				APIID:       "000001",
+				SendingBank: "014",
+				TransRef:    "015166150934BPP04578",
+				CountryCode: "TH",
+				CRC:         "0885",
+				Bank:        &ThaiBank{Code: "014", Abbreviation: "SCB", Name: "Siam Commercial Bank"},
+			},
+		},
+		{
+			name:     "unknown bank code",
+			qrString: slipUnknownQR,
+			expected: &SlipQR{
+				APIID:       "000001",
+				SendingBank: "999",
+				TransRef:    "REF1",
+				CountryCode: "TH",
+				CRC:         "DFA7",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slip, err := DecodeSlipQR(tt.qrString)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, slip)
+		})
+	}
+}
+
+func TestDecodeSlipQR_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		qrString string
+		wantErr  error
+	}{
+		{name: "too short", qrString: "0002", wantErr: ErrEMVTooShort},
+		{name: "EMV merchant QR", qrString: staticPromptPayQR, wantErr: ErrEMVCRCNotFound},
+		{name: "CRC mismatch", qrString: slipKBankQR[:len(slipKBankQR)-4] + "0000", wantErr: ErrEMVCRCMismatch},
+		{name: "missing transaction reference", qrString: "0016000600000101030045102TH91047752", wantErr: ErrInvalidSlipQR},
+		{name: "invalid bank code", qrString: "002400060000010102040204REF15102TH9104E3BD", wantErr: ErrInvalidSlipQR},
+		{name: "missing country code", qrString: "00370006000001010300402162023051912345ABC91043D44", wantErr: ErrInvalidSlipQR},
+		{name: "non-Thai country code", qrString: "00370006000001010300402162023051912345ABC5102KH91045B44", wantErr: ErrInvalidSlipQR},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slip, err := DecodeSlipQR(tt.qrString)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Nil(t, slip)
+		})
+	}
+}
+
+func TestIsSlipQR(t *testing.T) {
+	assert.True(t, IsSlipQR(slipKBankQR))
+	assert.False(t, IsSlipQR(staticPromptPayQR))
+	assert.False(t, IsSlipQR("9104"))
+}
+
+func TestLookupThaiBank(t *testing.T) {
+	bank, ok := LookupThaiBank("002")
+	assert.True(t, ok)
+	assert.Equal(t, "BBL", bank.Abbreviation)
+
+	_, ok = LookupThaiBank("999")
+	assert.False(t, ok)
+}
@@ -0,0 +1,82 @@
+package xstr
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// upiCountryCode is the country of UPI payees.
+const upiCountryCode = "IN"
+
+// ErrInvalidUPILink is returned when a UPI payment link is malformed.
+var ErrInvalidUPILink = errors.New("invalid UPI payment link")
+
+// UPILink represents a UPI payment link such as "upi://pay?pa=shop@bank&pn=Shop&am=10.00".
+type UPILink struct {
+	PayeeAddress   string `json:"pa"`           // Virtual payment address, e.g. "shop@okbank"
+	PayeeName      string `json:"pn,omitempty"` // Payee name
+	Amount         string `json:"am,omitempty"` // Amount in decimal form, e.g. "10.50"
+	Currency       string `json:"cu,omitempty"` // ISO 4217 alphabetic code, "INR" when omitted
+	TransactionRef string `json:"tr,omitempty"` // Transaction reference
+	TransactionID  string `json:"tid,omitempty"`
+	Note           string `json:"tn,omitempty"` // Transaction note shown to the payer
+	MCC            string `json:"mc,omitempty"` // Merchant category code
+}
+
+// ParseUPILink parses a UPI payment link. The payee address is required.
+//
+// Examples:
+//   - ParseUPILink("upi://pay?pa=shop@okbank&pn=Shop&am=10.50&cu=INR") -> {PayeeAddress: "shop@okbank", Amount: "10.50", ...}
+//   - ParseUPILink("upi://pay?pn=Shop") -> nil, ErrInvalidUPILink
+func ParseUPILink(link string) (*UPILink, error) {
+	u, err := url.Parse(strings.TrimSpace(link))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidUPILink, err)
+	}
+	if !strings.EqualFold(u.Scheme, "upi") || !strings.EqualFold(u.Host, "pay") {
+		return nil, fmt.Errorf("%w: expected upi://pay, got %s://%s", ErrInvalidUPILink, u.Scheme, u.Host)
+	}
+
+	query := u.Query()
+	upi := &UPILink{
+		PayeeAddress:   query.Get("pa"),
+		PayeeName:      query.Get("pn"),
+		Amount:         query.Get("am"),
+		Currency:       query.Get("cu"),
+		TransactionRef: query.Get("tr"),
+		TransactionID:  query.Get("tid"),
+		Note:           query.Get("tn"),
+		MCC:            query.Get("mc"),
+	}
+	if name, handle, ok := strings.Cut(upi.PayeeAddress, "@"); !ok || name == "" || handle == "" {
+		return nil, fmt.Errorf("%w: invalid payee address %q", ErrInvalidUPILink, upi.PayeeAddress)
+	}
+	if upi.Currency == "" {
+		upi.Currency = "INR"
+	}
+	return upi, nil
+}
+
+// PaymentIntent converts the UPI link to a PaymentIntent paid to the virtual payment address.
+func (l *UPILink) PaymentIntent() (*PaymentIntent, error) {
+	intent := &PaymentIntent{
+		Scheme:        QRSchemeUPI,
+		PayeeID:       l.PayeeAddress,
+		PayeeType:     PayeeTypeVPA,
+		PayeeName:     l.PayeeName,
+		Currency:      l.Currency,
+		CountryCode:   upiCountryCode,
+		Reference1:    l.TransactionRef,
+		Reference2:    l.TransactionID,
+		POIMethodType: POITypeStatic,
+	}
+	if l.Amount != "" {
+		intent.POIMethodType = POITypeDynamic
+		if err := intent.SetAmount(l.Amount); err != nil {
+			return nil, err
+		}
+	}
+	return intent, nil
+}
@@ -0,0 +1,76 @@
+package xstr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUPILink(t *testing.T) {
+	tests := []struct {
+		name     string
+		link     string
+		expected *UPILink
+		wantErr  bool
+	}{
+		{
+			name: "full link",
+			link: "upi://pay?pa=shop@okbank&pn=Chai%20Point&am=10.50&cu=INR&tr=ORD123&tn=Tea&mc=5812",
+			expected: &UPILink{
+				PayeeAddress:   "shop@okbank",
+				PayeeName:      "Chai Point",
+				Amount:         "10.50",
+				Currency:       "INR",
+				TransactionRef: "ORD123",
+				Note:           "Tea",
+				MCC:            "5812",
+			},
+		},
+		{
+			name:     "currency defaults to INR",
+			link:     " UPI://pay?pa=friend@upi\n",
+			expected: &UPILink{PayeeAddress: "friend@upi", Currency: "INR"},
+		},
+		{name: "missing payee address", link: "upi://pay?pn=Shop", wantErr: true},
+		{name: "invalid payee address", link: "upi://pay?pa=@okbank", wantErr: true},
+		{name: "wrong scheme", link: "https://pay?pa=shop@okbank", wantErr: true},
+		{name: "wrong action", link: "upi://mandate?pa=shop@okbank", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseUPILink(tt.link)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidUPILink)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestUPILink_PaymentIntent(t *testing.T) {
+	upi, err := ParseUPILink("upi://pay?pa=shop@okbank&pn=Chai%20Point&am=10.50&tr=ORD123")
+	require.NoError(t, err)
+
+	intent, err := upi.PaymentIntent()
+	require.NoError(t, err)
+	assert.Equal(t, &PaymentIntent{
+		Scheme:        QRSchemeUPI,
+		PayeeID:       "shop@okbank",
+		PayeeType:     PayeeTypeVPA,
+		PayeeName:     "Chai Point",
+		AmountMinor:   1050,
+		Currency:      "INR",
+		CountryCode:   "IN",
+		Reference1:    "ORD123",
+		POIMethodType: POITypeDynamic,
+	}, intent)
+
+	upi.Amount = "ten"
+	_, err = upi.PaymentIntent()
+	assert.ErrorIs(t, err, ErrEMVInvalidAmount)
+}